@@ -0,0 +1,119 @@
+// Command gemini is the in-tree PostureBackend server for Google
+// Gemini. It is what "--provider gemini" resolves to when main.go
+// dispatches through pkg/backend instead of calling Gemini directly:
+// pkg/backend.Load spawns "<backend-dir>/gemini --socket <path>" and
+// talks to it over that Unix socket, so Gemini lives behind the same
+// PostureBackend interface as any externally supplied provider.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+
+	pb "github.com/pranjal-lnct/PostureAnalysis/pkg/backend/proto"
+)
+
+func main() {
+	socketPtr := flag.String("socket", "", "Unix socket to listen on (set by pkg/backend.Load)")
+	flag.Parse()
+	if *socketPtr == "" {
+		log.Fatal("Error: --socket is required")
+	}
+
+	apiKey := os.Getenv("GOOGLE_API_KEY")
+	if apiKey == "" {
+		apiKey = os.Getenv("GEMINI_API_KEY")
+	}
+	if apiKey == "" {
+		log.Fatal("Error: GOOGLE_API_KEY not set")
+	}
+
+	modelName := os.Getenv("GEMINI_MODEL")
+	if modelName == "" {
+		modelName = "gemini-3-flash-preview"
+	}
+
+	os.Remove(*socketPtr)
+	lis, err := net.Listen("unix", *socketPtr)
+	if err != nil {
+		log.Fatalf("Error listening on %s: %v", *socketPtr, err)
+	}
+
+	srv := grpc.NewServer()
+	pb.RegisterPostureBackendServer(srv, &geminiBackend{apiKey: apiKey, modelName: modelName})
+
+	fmt.Printf("gemini backend listening on %s (model %s)\n", *socketPtr, modelName)
+	if err := srv.Serve(lis); err != nil {
+		log.Fatalf("Error serving: %v", err)
+	}
+}
+
+type geminiBackend struct {
+	pb.UnimplementedPostureBackendServer
+	apiKey    string
+	modelName string
+}
+
+func (b *geminiBackend) Health(ctx context.Context, req *pb.HealthRequest) (*pb.HealthResponse, error) {
+	return &pb.HealthResponse{Ready: true}, nil
+}
+
+// Analyze mirrors main.go's former runGemini, but reads images from
+// the request's ImagePart bytes instead of file paths, since the
+// caller already has them in memory on the other side of the socket.
+func (b *geminiBackend) Analyze(ctx context.Context, req *pb.AnalyzeRequest) (*pb.AnalyzeResponse, error) {
+	client, err := genai.NewClient(ctx, option.WithAPIKey(b.apiKey))
+	if err != nil {
+		return nil, fmt.Errorf("creating Gemini client: %w", err)
+	}
+	defer client.Close()
+
+	model := client.GenerativeModel(b.modelName)
+	model.SetTemperature(0.0)
+	model.SetTopK(1)
+	model.ResponseMIMEType = "application/json"
+
+	var parts []genai.Part
+	parts = append(parts, genai.Text(req.PromptText))
+	parts = append(parts, genai.Text("Respond with JSON matching this schema: "+req.SchemaJson))
+
+	for _, img := range req.Images {
+		if len(img.Data) == 0 {
+			parts = append(parts, genai.Text(fmt.Sprintf("[%s] - Image not found", img.Label)))
+			continue
+		}
+		mimeType := "jpeg"
+		if strings.Contains(img.MimeType, "png") {
+			mimeType = "png"
+		}
+		parts = append(parts, genai.Text(fmt.Sprintf("[%s]", img.Label)))
+		parts = append(parts, genai.ImageData(mimeType, img.Data))
+	}
+
+	resp, err := model.GenerateContent(ctx, parts...)
+	if err != nil {
+		return nil, fmt.Errorf("generating content: %w", err)
+	}
+
+	var result strings.Builder
+	for _, cand := range resp.Candidates {
+		if cand.Content == nil {
+			continue
+		}
+		for _, part := range cand.Content.Parts {
+			if txt, ok := part.(genai.Text); ok {
+				result.WriteString(string(txt))
+			}
+		}
+	}
+	return &pb.AnalyzeResponse{ResultJson: result.String()}, nil
+}
@@ -0,0 +1,110 @@
+// Command openai is the in-tree PostureBackend server for OpenAI,
+// spawned by pkg/backend.Load as "<backend-dir>/openai --socket
+// <path>" the same way the gemini backend is, so OpenAI lives behind
+// the same PostureBackend interface as any externally supplied
+// provider instead of being special-cased in the core CLI.
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+
+	openai "github.com/sashabaranov/go-openai"
+	"google.golang.org/grpc"
+
+	pb "github.com/pranjal-lnct/PostureAnalysis/pkg/backend/proto"
+)
+
+func main() {
+	socketPtr := flag.String("socket", "", "Unix socket to listen on (set by pkg/backend.Load)")
+	flag.Parse()
+	if *socketPtr == "" {
+		log.Fatal("Error: --socket is required")
+	}
+
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		log.Fatal("Error: OPENAI_API_KEY not set")
+	}
+
+	modelName := os.Getenv("OPENAI_MODEL")
+	if modelName == "" {
+		modelName = "gpt-4o-mini"
+	}
+
+	os.Remove(*socketPtr)
+	lis, err := net.Listen("unix", *socketPtr)
+	if err != nil {
+		log.Fatalf("Error listening on %s: %v", *socketPtr, err)
+	}
+
+	srv := grpc.NewServer()
+	pb.RegisterPostureBackendServer(srv, &openAIBackend{client: openai.NewClient(apiKey), modelName: modelName})
+
+	fmt.Printf("openai backend listening on %s (model %s)\n", *socketPtr, modelName)
+	if err := srv.Serve(lis); err != nil {
+		log.Fatalf("Error serving: %v", err)
+	}
+}
+
+type openAIBackend struct {
+	pb.UnimplementedPostureBackendServer
+	client    *openai.Client
+	modelName string
+}
+
+func (b *openAIBackend) Health(ctx context.Context, req *pb.HealthRequest) (*pb.HealthResponse, error) {
+	return &pb.HealthResponse{Ready: true}, nil
+}
+
+// Analyze mirrors main.go's former runOpenAI, but reads images from
+// the request's ImagePart bytes instead of file paths, since the
+// caller already has them in memory on the other side of the socket.
+func (b *openAIBackend) Analyze(ctx context.Context, req *pb.AnalyzeRequest) (*pb.AnalyzeResponse, error) {
+	var content []openai.ChatMessagePart
+	content = append(content, openai.ChatMessagePart{Type: openai.ChatMessagePartTypeText, Text: req.PromptText})
+	content = append(content, openai.ChatMessagePart{Type: openai.ChatMessagePartTypeText, Text: "Respond with JSON matching this schema: " + req.SchemaJson})
+
+	for _, img := range req.Images {
+		if len(img.Data) == 0 {
+			content = append(content, openai.ChatMessagePart{Type: openai.ChatMessagePartTypeText, Text: fmt.Sprintf("[%s] - Image not found", img.Label)})
+			continue
+		}
+		mimeType := img.MimeType
+		if mimeType == "" {
+			mimeType = "image/jpeg"
+		}
+		content = append(content, openai.ChatMessagePart{Type: openai.ChatMessagePartTypeText, Text: fmt.Sprintf("[%s]", img.Label)})
+		content = append(content, openai.ChatMessagePart{
+			Type: openai.ChatMessagePartTypeImageURL,
+			ImageURL: &openai.ChatMessageImageURL{
+				URL:    fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(img.Data)),
+				Detail: openai.ImageURLDetailAuto,
+			},
+		})
+	}
+
+	resp, err := b.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model: b.modelName,
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleUser, MultiContent: content},
+		},
+		Temperature: 0,
+		ResponseFormat: &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONObject,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("generating content: %w", err)
+	}
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("no response from OpenAI")
+	}
+
+	return &pb.AnalyzeResponse{ResultJson: resp.Choices[0].Message.Content}, nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/embeddings"
+)
+
+// indexAnalysis computes an embedding of the finished analysis
+// (clinical reasoning plus the serialized metric values) and appends
+// it, alongside the timestamp and PostureScore, to output/index.jsonl.
+// This is what turns a series of one-shot runs into a history the
+// `compare` subcommand can search.
+func indexAnalysis(outputBase, timestamp string, analysisData map[string]interface{}) {
+	providerName := os.Getenv("EMBEDDINGS_PROVIDER")
+	if providerName == "" {
+		providerName = os.Getenv("AI_PROVIDER")
+	}
+	if providerName == "" || providerName == "ollama" {
+		providerName = "gemini"
+	}
+
+	provider, err := embeddings.New(providerName)
+	if err != nil {
+		log.Printf("Warning: skipping history index, could not set up embeddings provider: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	embedding, err := provider.Embed(ctx, embeddingText(analysisData))
+	if err != nil {
+		log.Printf("Warning: skipping history index, embedding failed: %v", err)
+		return
+	}
+
+	score, _ := analysisData["PostureScore"].(int)
+	entry := embeddings.Entry{Timestamp: timestamp, PostureScore: score, Embedding: embedding}
+
+	indexPath := filepath.Join(outputBase, "index.jsonl")
+	if err := embeddings.AppendToIndex(indexPath, entry); err != nil {
+		log.Printf("Warning: could not append to %s: %v", indexPath, err)
+		return
+	}
+	fmt.Printf("Indexed run %s in %s\n", timestamp, indexPath)
+}
+
+// embeddingText concatenates the clinical reasoning with the
+// serialized region metrics, which is what actually gets embedded.
+func embeddingText(analysisData map[string]interface{}) string {
+	reasoning, _ := analysisData["clinical_reasoning"].(string)
+
+	regionsJSON := ""
+	if regions, ok := analysisData["Regions"]; ok {
+		if b, err := json.Marshal(regions); err == nil {
+			regionsJSON = string(b)
+		}
+	}
+
+	return reasoning + "\n" + regionsJSON
+}
+
+// runCompare finds the K nearest prior sessions to a given analysis
+// directory by cosine similarity over their embeddings, and prints
+// which metrics improved or regressed relative to each.
+func runCompare(args []string) {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	dirPtr := fs.String("dir", "", "Path to the output/<timestamp> directory to compare")
+	outputBasePtr := fs.String("output-base", "output", "Base output directory containing index.jsonl")
+	kPtr := fs.Int("k", 3, "Number of nearest prior sessions to compare against")
+	fs.Parse(args)
+
+	if *dirPtr == "" {
+		log.Fatal("Error: --dir is required")
+	}
+
+	analysisPath := filepath.Join(*dirPtr, "analysis.json")
+	dataBytes, err := os.ReadFile(analysisPath)
+	if err != nil {
+		log.Fatalf("Error reading %s: %v", analysisPath, err)
+	}
+	var analysisData map[string]interface{}
+	if err := json.Unmarshal(dataBytes, &analysisData); err != nil {
+		log.Fatalf("Error parsing %s: %v", analysisPath, err)
+	}
+	buildRegionsAndScore(analysisData)
+
+	providerName := os.Getenv("EMBEDDINGS_PROVIDER")
+	if providerName == "" {
+		providerName = "gemini"
+	}
+	provider, err := embeddings.New(providerName)
+	if err != nil {
+		log.Fatalf("Error setting up embeddings provider: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	query, err := provider.Embed(ctx, embeddingText(analysisData))
+	if err != nil {
+		log.Fatalf("Error embedding %s: %v", analysisPath, err)
+	}
+
+	indexPath := filepath.Join(*outputBasePtr, "index.jsonl")
+	entries, err := embeddings.LoadIndex(indexPath)
+	if err != nil {
+		log.Fatalf("Error loading %s: %v", indexPath, err)
+	}
+	if len(entries) == 0 {
+		fmt.Println("No prior sessions in the index yet; nothing to compare against.")
+		return
+	}
+
+	neighbors := embeddings.Nearest(entries, query, *kPtr)
+	currentScore, _ := analysisData["PostureScore"].(int)
+
+	fmt.Printf("Current session: PostureScore=%d\n\n", currentScore)
+	fmt.Println("Nearest prior sessions:")
+	for _, n := range neighbors {
+		delta := currentScore - n.PostureScore
+		trend := "unchanged"
+		if delta > 0 {
+			trend = fmt.Sprintf("improved by %d", delta)
+		} else if delta < 0 {
+			trend = fmt.Sprintf("regressed by %d", -delta)
+		}
+		fmt.Printf("  - %s: PostureScore=%d (%s)\n", n.Timestamp, n.PostureScore, trend)
+	}
+}
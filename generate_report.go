@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -9,17 +10,53 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
+	"time"
+
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/aggregate"
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/domainscore"
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/exercises"
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/progress"
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/tts"
 )
 
+// regionIcons maps each region title to the SVG path main.go's
+// template also uses for that region.
+var regionIcons = map[string]string{
+	"Head & Neck":          "M16 7a4 4 0 11-8 0 4 4 0 018 0zM12 14a7 7 0 00-7 7h14a7 7 0 00-7-7z",
+	"Shoulders & Scapulae": "M19 11H5m14 0a2 2 0 012 2v6a2 2 0 01-2 2H5a2 2 0 01-2-2v-6a2 2 0 012-2m14 0V9a2 2 0 00-2-2M5 11V9a2 2 0 012-2m0 0V5a2 2 0 012-2h6a2 2 0 012 2v2M7 7h10",
+	"Spine":                "M4 6h16M4 10h16M4 14h16M4 18h16",
+	"Pelvis & Hips":        "M12 6V4m0 2a2 2 0 100 4m0-4a2 2 0 110 4m-6 8a2 2 0 100-4m0 4a2 2 0 110-4m0 4v2m0-6V4m6 6v10m6-2a2 2 0 100-4m0 4a2 2 0 110-4m0 4v2m0-6V4",
+	"Lower Extremities":    "M13.828 10.172a4 4 0 00-5.656 0l-4 4a4 4 0 105.656 5.656l1.102-1.101m-.758-4.899a4 4 0 005.656 0l4-4a4 4 0 00-5.656-5.656l-1.1 1.1",
+	"Ankles & Feet":        "M3.055 11H5a2 2 0 012 2v1a2 2 0 002 2 2 2 0 012 2v2.945M8 3.935V5.5A2.5 2.5 0 0010.5 8h.5a2 2 0 012 2 2 2 0 104 0 2 2 0 012-2h1.064M15 20.488V18a2 2 0 012-2h3.064",
+}
+
+// buildRegions constructs the same Regions list main.go builds for
+// its template, from a raw analysis.json's top-level region keys.
+func buildRegions(analysisData map[string]interface{}) []map[string]interface{} {
+	return []map[string]interface{}{
+		{"Title": "Head & Neck", "Data": analysisData["head_neck"], "Icon": regionIcons["Head & Neck"]},
+		{"Title": "Shoulders & Scapulae", "Data": analysisData["shoulders"], "Icon": regionIcons["Shoulders & Scapulae"]},
+		{"Title": "Spine", "Data": analysisData["spine"], "Icon": regionIcons["Spine"]},
+		{"Title": "Pelvis & Hips", "Data": analysisData["pelvis"], "Icon": regionIcons["Pelvis & Hips"]},
+		{"Title": "Lower Extremities", "Data": analysisData["lower_extremities"], "Icon": regionIcons["Lower Extremities"]},
+		{"Title": "Ankles & Feet", "Data": analysisData["ankles_feet"], "Icon": regionIcons["Ankles & Feet"]},
+	}
+}
+
 func main() {
 	jsonPath := flag.String("json", "", "Path to analysis.json file")
+	narratePtr := flag.Bool("narrate", false, "Synthesize an audio narration of the report (provider via TTS_PROVIDER: openai, gemini, or piper)")
+	exercisesDirPtr := flag.String("exercises-dir", "", "Directory of exercise *.toml/*.md rule files (overrides EXERCISES_DIR, default ./exercises)")
+	domainWeightsPtr := flag.String("domain-weights", "", `Comma-separated Region=weight pairs for the composite PostureScore (overrides DOMAIN_WEIGHTS, default equal weights), e.g. "Spine=2,Head & Neck=1.5"`)
+	comparePtr := flag.String("compare", "", "A directory of past output/<timestamp>/analysis.json runs, or a comma-separated list of analysis.json paths, to diff into a progress.html")
 	flag.Parse()
 
 	if *jsonPath == "" {
 		// Default to the path the user mentioned, or just fail
 		fmt.Println("Usage: go run generate_report.go --json <path/to/analysis.json>")
-		
+
 		// For convenience given the user request, let's try the specific path if it exists
 		defaultPath := "output/2026-01-03_21-58-26/analysis.json"
 		if _, err := os.Stat(defaultPath); err == nil {
@@ -43,7 +80,7 @@ func main() {
 
 	// Output dir should remain same as json dir
 	outputDir := filepath.Dir(*jsonPath)
-	
+
 	// Inject images by looking for them
 	imagesMap := make(map[string]string)
 	findImage := func(prefix string) string {
@@ -57,152 +94,274 @@ func main() {
 		}
 		return ""
 	}
-	
-	if img := findImage("front"); img != "" { imagesMap["front"] = img }
-	if img := findImage("left"); img != "" { imagesMap["left"] = img }
-	if img := findImage("right"); img != "" { imagesMap["right"] = img }
-	if img := findImage("back"); img != "" { imagesMap["back"] = img }
-	
+
+	if img := findImage("front"); img != "" {
+		imagesMap["front"] = img
+	}
+	if img := findImage("left"); img != "" {
+		imagesMap["left"] = img
+	}
+	if img := findImage("right"); img != "" {
+		imagesMap["right"] = img
+	}
+	if img := findImage("back"); img != "" {
+		imagesMap["back"] = img
+	}
+
 	analysisData["input_images"] = imagesMap
 
-    // Helper string maps for icons
-        icons := map[string]string{
-            "Head & Neck":           "M16 7a4 4 0 11-8 0 4 4 0 018 0zM12 14a7 7 0 00-7 7h14a7 7 0 00-7-7z",
-            "Shoulders & Scapulae":  "M19 11H5m14 0a2 2 0 012 2v6a2 2 0 01-2 2H5a2 2 0 01-2-2v-6a2 2 0 012-2m14 0V9a2 2 0 00-2-2M5 11V9a2 2 0 012-2m0 0V5a2 2 0 012-2h6a2 2 0 012 2v2M7 7h10",
-            "Spine":                 "M4 6h16M4 10h16M4 14h16M4 18h16",
-            "Pelvis & Hips":         "M12 6V4m0 2a2 2 0 100 4m0-4a2 2 0 110 4m-6 8a2 2 0 100-4m0 4a2 2 0 110-4m0 4v2m0-6V4m6 6v10m6-2a2 2 0 100-4m0 4a2 2 0 110-4m0 4v2m0-6V4",
-            "Lower Extremities":     "M13.828 10.172a4 4 0 00-5.656 0l-4 4a4 4 0 105.656 5.656l1.102-1.101m-.758-4.899a4 4 0 005.656 0l4-4a4 4 0 00-5.656-5.656l-1.1 1.1",
-            "Ankles & Feet":         "M3.055 11H5a2 2 0 012 2v1a2 2 0 002 2 2 2 0 012 2v2.945M8 3.935V5.5A2.5 2.5 0 0010.5 8h.5a2 2 0 012 2 2 2 0 104 0 2 2 0 012-2h1.064M15 20.488V18a2 2 0 012-2h3.064",
-        }
-
-        // Construct Regions list manually
-        regions := []map[string]interface{}{
-            {"Title": "Head & Neck", "Data": analysisData["head_neck"], "Icon": icons["Head & Neck"]},
-            {"Title": "Shoulders & Scapulae", "Data": analysisData["shoulders"], "Icon": icons["Shoulders & Scapulae"]},
-            {"Title": "Spine", "Data": analysisData["spine"], "Icon": icons["Spine"]},
-            {"Title": "Pelvis & Hips", "Data": analysisData["pelvis"], "Icon": icons["Pelvis & Hips"]},
-            {"Title": "Lower Extremities", "Data": analysisData["lower_extremities"], "Icon": icons["Lower Extremities"]},
-            {"Title": "Ankles & Feet", "Data": analysisData["ankles_feet"], "Icon": icons["Ankles & Feet"]},
-        }
-        // Calculate Posture Score (100 is perfect)
-        score := 100
-        for _, region := range regions {
-            dataMap, ok := region["Data"].(map[string]interface{})
-            if !ok { continue }
-            
-            // findings string doesn't count
-            
-            for _, v := range dataMap {
-                 if metric, ok := v.(map[string]interface{}); ok {
-                     sev, _ := metric["severity"].(string)
-                     switch sev {
-                     case "severe":
-                         score -= 15
-                     case "moderate":
-                         score -= 10
-                     case "mild":
-                         score -= 5
-                     }
-                 }
-            }
-        }
-        if score < 0 { score = 0 }
-        analysisData["PostureScore"] = score
-
-        // Add to analysisData so template can use it
-        analysisData["Regions"] = regions
-
-        // Generate exercise recommendations (imported from main.go logic)
-        exercises := generateExerciseRecommendationsLocal(analysisData)
-        analysisData["Exercises"] = exercises
+	regions := buildRegions(analysisData)
+
+	weights := domainscore.ParseWeights(domainscore.WeightsEnv(*domainWeightsPtr))
+	domainScores, domainDetails, compositeScore := domainscore.Score(regions, weights)
+	analysisData["PostureScore"] = compositeScore
+	analysisData["DomainScores"] = domainScores
+	analysisData["DomainDetails"] = domainDetails
+
+	// Add to analysisData so template can use it
+	analysisData["Regions"] = regions
+
+	if scoresJSON, err := json.MarshalIndent(map[string]interface{}{
+		"posture_score":  compositeScore,
+		"domain_scores":  domainScores,
+		"domain_details": domainDetails,
+	}, "", "  "); err == nil {
+		os.WriteFile(filepath.Join(outputDir, "scores.json"), scoresJSON, 0644)
+	}
+
+	// Generate exercise recommendations from the rule catalog
+	catalog, err := exercises.Load(exercises.Dir(*exercisesDirPtr))
+	if err != nil {
+		log.Printf("Warning: could not load exercise catalog: %v", err)
+		catalog = &exercises.Catalog{}
+	}
+	recommended := catalog.Recommend(analysisData)
+	analysisData["Exercises"] = recommended
+
+	if *narratePtr {
+		generateNarration(outputDir, analysisData)
+	}
 
 	generateHTMLReport(outputDir, analysisData)
+
+	if *comparePtr != "" {
+		generateProgress(outputDir, *jsonPath, *comparePtr, weights, catalog, regions, compositeScore, domainScores, recommended)
+	}
 }
 
-func generateExerciseRecommendationsLocal(analysisData map[string]interface{}) []map[string]string {
-	exercises := []map[string]string{}
-
-	// Check for forward head posture
-	if globalAlignment, ok := analysisData["global_alignment"].(map[string]interface{}); ok {
-		if fhp, ok := globalAlignment["forward_head_posture"].(map[string]interface{}); ok {
-			if severity, _ := fhp["severity"].(string); severity == "moderate" || severity == "severe" {
-				exercises = append(exercises, map[string]string{
-					"Name":        "Chin Tucks",
-					"Description": "Gently retract chin backward (like making a double chin), hold for 5 seconds. Keep eyes level.",
-					"Frequency":   "3 sets of 10 reps, 2x daily",
-					"Purpose":     "Strengthens deep neck flexors and reduces forward head posture",
-				})
-			}
-		}
+// generateProgress loads every past run named by --compare (plus the
+// run just generated), scores each one the same way the current run
+// was scored above, aligns them into a progress.History, and persists
+// it as history.json and progress.html next to report.html.
+func generateProgress(outputDir, currentJSONPath, compareArg string, weights map[string]float64, catalog *exercises.Catalog, currentRegions []map[string]interface{}, currentScore int, currentDomainScores map[string]int, currentExercises []exercises.Exercise) {
+	paths, err := resolveCompareRuns(compareArg)
+	if err != nil {
+		log.Printf("Warning: could not resolve --compare %q: %v", compareArg, err)
+		return
 	}
 
-	// Check for thoracic kyphosis
-	if spine, ok := analysisData["spine"].(map[string]interface{}); ok {
-		if kyphosis, ok := spine["thoracic_kyphosis"].(map[string]interface{}); ok {
-			if severity, _ := kyphosis["severity"].(string); severity == "moderate" || severity == "severe" {
-				exercises = append(exercises, map[string]string{
-					"Name":        "Thoracic Extensions",
-					"Description": "Place hands behind head, gently extend upper back over a foam roller or rolled towel. Hold 30 seconds.",
-					"Frequency":   "3-5 repetitions, 1-2x daily",
-					"Purpose":     "Improves thoracic spine mobility and reduces excessive kyphosis",
-				})
-			}
-		}
+	currentAbs, _ := filepath.Abs(currentJSONPath)
+
+	currentNames := make([]string, len(currentExercises))
+	for i, ex := range currentExercises {
+		currentNames[i] = ex.Name
+	}
+	currentRun := progress.Run{
+		Timestamp:    filepath.Base(filepath.Dir(currentJSONPath)),
+		Regions:      currentRegions,
+		PostureScore: currentScore,
+		DomainScores: currentDomainScores,
+		Exercises:    currentNames,
 	}
 
-	// Check for shoulder protraction
-	if shoulders, ok := analysisData["shoulders"].(map[string]interface{}); ok {
-		if protraction, ok := shoulders["shoulder_protraction"].(map[string]interface{}); ok {
-			if severity, _ := protraction["severity"].(string); severity == "moderate" || severity == "severe" {
-				exercises = append(exercises, map[string]string{
-					"Name":        "Scapular Retractions",
-					"Description": "Squeeze shoulder blades together as if holding a pencil between them. Hold for 5 seconds.",
-					"Frequency":   "3 sets of 15 reps, 2x daily",
-					"Purpose":     "Strengthens rhomboids and middle trapezius to improve shoulder posture",
-				})
-			}
+	var runs []progress.Run
+	for _, p := range paths {
+		if abs, _ := filepath.Abs(p); abs == currentAbs {
+			continue
 		}
+		run, err := loadHistoricalRun(p, weights, catalog)
+		if err != nil {
+			log.Printf("Warning: skipping %s in --compare: %v", p, err)
+			continue
+		}
+		runs = append(runs, run)
 	}
+	runs = append(runs, currentRun)
 
-	// Check for lumbar lordosis
-	if spine, ok := analysisData["spine"].(map[string]interface{}); ok {
-		if lordosis, ok := spine["lumbar_lordosis"].(map[string]interface{}); ok {
-			if severity, _ := lordosis["severity"].(string); severity == "moderate" || severity == "severe" {
-				exercises = append(exercises, map[string]string{
-					"Name":        "Pelvic Tilts",
-					"Description": "Lie on back with knees bent. Flatten lower back against floor by tilting pelvis. Hold 5 seconds.",
-					"Frequency":   "3 sets of 12 reps, 1-2x daily",
-					"Purpose":     "Activates core muscles and normalizes lumbar curve",
-				})
-			}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Timestamp < runs[j].Timestamp })
+
+	history := progress.Build(runs)
+
+	historyJSON, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		log.Printf("Warning: could not marshal history: %v", err)
+		return
+	}
+	historyPath := filepath.Join(outputDir, "history.json")
+	if err := os.WriteFile(historyPath, historyJSON, 0644); err != nil {
+		log.Printf("Warning: could not write %s: %v", historyPath, err)
+		return
+	}
+	fmt.Printf("History saved to: %s\n", historyPath)
+
+	generateProgressReport(outputDir, history)
+}
+
+// resolveCompareRuns expands --compare into a list of analysis.json
+// paths: every output/<timestamp>/analysis.json under a directory, or
+// the comma-separated paths given directly.
+func resolveCompareRuns(compareArg string) ([]string, error) {
+	if info, err := os.Stat(compareArg); err == nil && info.IsDir() {
+		matches, err := filepath.Glob(filepath.Join(compareArg, "*", "analysis.json"))
+		if err != nil {
+			return nil, fmt.Errorf("scanning %s: %w", compareArg, err)
 		}
+		return matches, nil
+	}
+	return strings.Split(compareArg, ","), nil
+}
+
+// loadHistoricalRun reads and scores a past run's analysis.json the
+// same way the current run is scored, so progress.Build compares like
+// with like.
+func loadHistoricalRun(path string, weights map[string]float64, catalog *exercises.Catalog) (progress.Run, error) {
+	dataBytes, err := os.ReadFile(path)
+	if err != nil {
+		return progress.Run{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(dataBytes, &data); err != nil {
+		return progress.Run{}, fmt.Errorf("parsing %s: %w", path, err)
 	}
 
-	// Check for knee hyperextension
-	if lowerExt, ok := analysisData["lower_extremities"].(map[string]interface{}); ok {
-		if hyperext, ok := lowerExt["knee_hyperextension"].(map[string]interface{}); ok {
-			if severity, _ := hyperext["severity"].(string); severity == "mild" || severity == "moderate" {
-				exercises = append(exercises, map[string]string{
-					"Name":        "Quadriceps Strengthening",
-					"Description": "Seated leg extensions with slight knee bend. Focus on controlled movement without locking knees.",
-					"Frequency":   "3 sets of 10 reps, 3x weekly",
-					"Purpose":     "Improves knee control and reduces hyperextension tendency",
-				})
+	regions := buildRegions(data)
+	domainScores, _, compositeScore := domainscore.Score(regions, weights)
+
+	recs := catalog.Recommend(data)
+	names := make([]string, len(recs))
+	for i, ex := range recs {
+		names[i] = ex.Name
+	}
+
+	return progress.Run{
+		Timestamp:    filepath.Base(filepath.Dir(path)),
+		Regions:      regions,
+		PostureScore: compositeScore,
+		DomainScores: domainScores,
+		Exercises:    names,
+	}, nil
+}
+
+// generateProgressReport renders history into progress.html using
+// progress_template.html, the same way generateHTMLReport renders
+// report.html from template.html - neither template file ships in
+// this tree, so like that one, this is wired but unrendered until a
+// template is supplied; the log warning below makes that visible.
+func generateProgressReport(outputDir string, history progress.History) {
+	tmplPath := "progress_template.html"
+
+	tmplFuncs := template.FuncMap{
+		"sparkline": func(t progress.MetricTrend) template.HTML {
+			return aggregate.Sparkline(t.Samples())
+		},
+	}
+
+	tmpl, err := template.New("progress_template.html").Funcs(tmplFuncs).ParseFiles(tmplPath)
+	if err != nil {
+		log.Printf("Warning: Could not parse progress HTML template: %v", err)
+		return
+	}
+
+	reportPath := filepath.Join(outputDir, "progress.html")
+	ctxFile, err := os.Create(reportPath)
+	if err != nil {
+		log.Printf("Warning: Could not create progress.html: %v", err)
+		return
+	}
+	defer ctxFile.Close()
+
+	if err := tmpl.Execute(ctxFile, history); err != nil {
+		log.Printf("Warning: Could not execute progress HTML template: %v", err)
+	} else {
+		fmt.Printf("Progress report saved to: %s\n", reportPath)
+	}
+}
+
+// generateNarration builds a short narration script from the clinical
+// reasoning, priority areas and exercise plan, synthesizes it with
+// whatever TTS_PROVIDER names, and records the resulting file under
+// analysisData["NarrationAudio"] so report.html can embed it in an
+// <audio> element.
+func generateNarration(outputDir string, analysisData map[string]interface{}) {
+	providerName := os.Getenv("TTS_PROVIDER")
+	if providerName == "" {
+		providerName = "openai"
+	}
+
+	provider, err := tts.New(providerName)
+	if err != nil {
+		log.Printf("Warning: could not set up TTS provider: %v", err)
+		return
+	}
+
+	script := buildNarrationScript(analysisData)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	audio, ext, err := provider.Synthesize(ctx, script)
+	if err != nil {
+		log.Printf("Warning: narration synthesis failed: %v", err)
+		return
+	}
+
+	narrationPath := filepath.Join(outputDir, "analysis"+ext)
+	if err := os.WriteFile(narrationPath, audio, 0644); err != nil {
+		log.Printf("Warning: could not save narration audio: %v", err)
+		return
+	}
+
+	analysisData["NarrationAudio"] = filepath.Base(narrationPath)
+	fmt.Printf("Narration saved to: %s\n", narrationPath)
+}
+
+// buildNarrationScript renders the narration template clinicians can
+// customize per region; for now this is the single built-in wording,
+// but it is kept as its own function so a template file can replace
+// the fmt.Sprintf below without touching generateNarration.
+func buildNarrationScript(analysisData map[string]interface{}) string {
+	var b strings.Builder
+
+	if reasoning, ok := analysisData["clinical_reasoning"].(string); ok && reasoning != "" {
+		b.WriteString(reasoning)
+		b.WriteString(" ")
+	}
+
+	if inference, ok := analysisData["clinical_inference"].(map[string]interface{}); ok {
+		if areas, ok := inference["priority_areas"].([]interface{}); ok && len(areas) > 0 {
+			b.WriteString("Priority areas to address: ")
+			for i, a := range areas {
+				if i > 0 {
+					b.WriteString(", ")
+				}
+				b.WriteString(fmt.Sprintf("%v", a))
 			}
+			b.WriteString(". ")
 		}
 	}
 
-	// Add general postural awareness exercise if multiple issues
-	if len(exercises) >= 3 {
-		exercises = append(exercises, map[string]string{
-			"Name":        "Postural Awareness Practice",
-			"Description": "Stand against wall with heels, buttocks, shoulders, and head touching. Hold 30 seconds while breathing normally.",
-			"Frequency":   "2-3 times daily",
-			"Purpose":     "Develops kinesthetic awareness of optimal alignment",
-		})
+	if recs, ok := analysisData["Exercises"].([]exercises.Exercise); ok && len(recs) > 0 {
+		b.WriteString("Recommended exercises: ")
+		for i, ex := range recs {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(ex.Name)
+		}
+		b.WriteString(".")
 	}
 
-	return exercises
+	return b.String()
 }
 
 func generateHTMLReport(outputDir string, data map[string]interface{}) {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
@@ -9,16 +10,25 @@ import (
 	"html/template"
 	"io"
 	"log"
+	"net/http"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"reflect"
 	"strings"
 	"time"
 
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
 	"github.com/google/generative-ai-go/genai"
 	"github.com/joho/godotenv"
 	openai "github.com/sashabaranov/go-openai"
 	"google.golang.org/api/option"
+
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/backend"
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/config"
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/domainscore"
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/exercises"
 )
 
 func main() {
@@ -27,6 +37,18 @@ func main() {
 		log.Println("Warning: No .env file found")
 	}
 
+	// `serve` is a subcommand rather than a flag so it can own its own
+	// flag set (--address, --upload-limit-mb, ...) without colliding
+	// with the one-shot CLI flags below.
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		runServe(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "compare" {
+		runCompare(os.Args[2:])
+		return
+	}
+
 	// Load provider config
 	provider := os.Getenv("AI_PROVIDER")
 	if provider == "" {
@@ -53,7 +75,16 @@ func main() {
 	rightPtr := flag.String("right", "right.jpg", "Path to right view image")
 	backPtr := flag.String("back", "back.jpg", "Path to back view image")
 	modelPtr := flag.String("model", "", "Model to use (overrides env). Examples: gemini-3-flash-preview, gpt-4o-mini")
-	providerPtr := flag.String("provider", "", "Provider: gemini or openai (overrides env)")
+	providerPtr := flag.String("provider", "", "Provider: gemini, openai, ollama, grpc (dials GRPC_BACKEND_ADDR), or the name of a backend binary under --backend-path (overrides env)")
+	backendPathPtr := flag.String("backend-path", "", "Directory to look up pluggable backend binaries in (overrides POSTURE_BACKEND_PATH, default ./backends)")
+	profilePtr := flag.String("profile", "", "Named profile from the models gallery to use (see --models-file); overrides --provider/--model")
+	modelsFilePtr := flag.String("models-file", "", "Path to the models gallery YAML (overrides MODELS_FILE env, default models.yaml)")
+	poseBackendURLPtr := flag.String("pose-backend-url", "", "Base URL of a pose keypoint model server (overrides POSE_BACKEND_URL); leave unset to skip preprocessing")
+	exercisesDirPtr := flag.String("exercises-dir", "", "Directory of exercise *.toml/*.md rule files (overrides EXERCISES_DIR, default ./exercises)")
+	domainWeightsPtr := flag.String("domain-weights", "", `Comma-separated Region=weight pairs for the composite PostureScore (overrides DOMAIN_WEIGHTS, default equal weights), e.g. "Spine=2,Head & Neck=1.5"`)
+	pdfPtr := flag.Bool("pdf", false, "Also render a self-contained report.pdf (requires a local Chrome/Chromium binary; see --pdf-page-size, --pdf-locale)")
+	pdfPageSizePtr := flag.String("pdf-page-size", "A4", "Page size for --pdf: A4, Letter, or Legal")
+	pdfLocalePtr := flag.String("pdf-locale", "en-US", "Locale for --pdf's dates and footer")
 	flag.Parse()
 
 	// Override provider/model from flags if provided
@@ -95,7 +126,7 @@ func main() {
 		}
 	}
 
-	// We copy the files to the output dir with standard names for easy reference, 
+	// We copy the files to the output dir with standard names for easy reference,
 	// or we could keep original names. Standard names seems cleaner for the archive.
 	copyFile(*frontPtr, "front"+filepath.Ext(*frontPtr))
 	copyFile(*leftPtr, "left"+filepath.Ext(*leftPtr))
@@ -113,15 +144,58 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
 	defer cancel()
 
+	// Extract keypoints/angles before the LLM call so the model gets
+	// numeric ground truth alongside the photos, and so main() has
+	// something to cross-check the model's narrative severities
+	// against once the JSON comes back.
+	poseResults := preprocessPose(ctx, outputDir, *poseBackendURLPtr, *frontPtr, *leftPtr, *rightPtr, *backPtr)
+	if len(poseResults) > 0 {
+		promptText += "\n\n" + posePromptBlock(poseResults)
+	}
+
 	var resultText string
+	var usedProfile string
 
 	fmt.Println("Starting Posture Analysis (Go)...")
-	fmt.Printf("Using provider: %s\n", provider)
 
-	if provider == "openai" {
-		resultText = runOpenAI(ctx, promptText, *frontPtr, *leftPtr, *rightPtr, *backPtr)
+	if *profilePtr != "" {
+		modelsFile := *modelsFilePtr
+		if modelsFile == "" {
+			modelsFile = os.Getenv("MODELS_FILE")
+		}
+		if modelsFile == "" {
+			modelsFile = "models.yaml"
+		}
+
+		gallery, err := config.Load(modelsFile)
+		if err != nil {
+			log.Fatalf("Error loading models gallery: %v", err)
+		}
+		chain, err := gallery.Chain(*profilePtr)
+		if err != nil {
+			log.Fatalf("Error resolving profile chain: %v", err)
+		}
+
+		resultText, usedProfile = runProfileChain(ctx, chain, promptText, *frontPtr, *leftPtr, *rightPtr, *backPtr, backend.Dir(*backendPathPtr), outputDir)
+		fmt.Printf("Profile %q produced the analysis\n", usedProfile)
 	} else {
-		resultText = runGemini(ctx, promptText, *frontPtr, *leftPtr, *rightPtr, *backPtr)
+		fmt.Printf("Using provider: %s\n", provider)
+		var err error
+		switch provider {
+		case "openai":
+			resultText, err = runOpenAI(ctx, promptText, *frontPtr, *leftPtr, *rightPtr, *backPtr)
+		case "gemini":
+			resultText, err = runGemini(ctx, promptText, *frontPtr, *leftPtr, *rightPtr, *backPtr)
+		case "ollama":
+			resultText, err = runOllama(ctx, promptText, *frontPtr, *leftPtr, *rightPtr, *backPtr)
+		case "grpc":
+			resultText, err = runGRPCBackend(ctx, promptText, *frontPtr, *leftPtr, *rightPtr, *backPtr, outputDir)
+		default:
+			resultText, err = runBackend(ctx, backend.Dir(*backendPathPtr), provider, promptText, *frontPtr, *leftPtr, *rightPtr, *backPtr, outputDir)
+		}
+		if err != nil {
+			log.Fatalf("Error running provider %q: %v", provider, err)
+		}
 	}
 
 	// Print to console
@@ -145,87 +219,100 @@ func main() {
 	cleanJson = strings.TrimPrefix(cleanJson, "```json")
 	cleanJson = strings.TrimPrefix(cleanJson, "```")
 	cleanJson = strings.TrimSuffix(cleanJson, "```")
-	
+
 	if err := json.Unmarshal([]byte(cleanJson), &analysisData); err != nil {
 		log.Printf("Warning: Could not parse JSON for HTML report: %v", err)
 	} else {
-        // Inject image paths relative to output folder
-        imagesMap := map[string]string{
-            "front": "front" + filepath.Ext(*frontPtr),
-            "left": "left" + filepath.Ext(*leftPtr),
-            "right": "right" + filepath.Ext(*rightPtr),
-            "back": "back" + filepath.Ext(*backPtr),
-        }
-        analysisData["input_images"] = imagesMap
-
-        // Helper string maps for icons
-        icons := map[string]string{
-            "Head & Neck":           "M16 7a4 4 0 11-8 0 4 4 0 018 0zM12 14a7 7 0 00-7 7h14a7 7 0 00-7-7z",
-            "Shoulders & Scapulae":  "M19 11H5m14 0a2 2 0 012 2v6a2 2 0 01-2 2H5a2 2 0 01-2-2v-6a2 2 0 012-2m14 0V9a2 2 0 00-2-2M5 11V9a2 2 0 012-2m0 0V5a2 2 0 012-2h6a2 2 0 012 2v2M7 7h10",
-            "Spine":                 "M4 6h16M4 10h16M4 14h16M4 18h16",
-            "Pelvis & Hips":         "M12 6V4m0 2a2 2 0 100 4m0-4a2 2 0 110 4m-6 8a2 2 0 100-4m0 4a2 2 0 110-4m0 4v2m0-6V4m6 6v10m6-2a2 2 0 100-4m0 4a2 2 0 110-4m0 4v2m0-6V4",
-            "Lower Extremities":     "M13.828 10.172a4 4 0 00-5.656 0l-4 4a4 4 0 105.656 5.656l1.102-1.101m-.758-4.899a4 4 0 005.656 0l4-4a4 4 0 00-5.656-5.656l-1.1 1.1",
-            "Ankles & Feet":         "M3.055 11H5a2 2 0 012 2v1a2 2 0 002 2 2 2 0 012 2v2.945M8 3.935V5.5A2.5 2.5 0 0010.5 8h.5a2 2 0 012 2 2 2 0 104 0 2 2 0 012-2h1.064M15 20.488V18a2 2 0 012-2h3.064",
-        }
-
-        // Construct Regions list manually
-        regions := []map[string]interface{}{
-            {"Title": "Head & Neck", "Data": analysisData["head_neck"], "Icon": icons["Head & Neck"]},
-            {"Title": "Shoulders & Scapulae", "Data": analysisData["shoulders"], "Icon": icons["Shoulders & Scapulae"]},
-            {"Title": "Spine", "Data": analysisData["spine"], "Icon": icons["Spine"]},
-            {"Title": "Pelvis & Hips", "Data": analysisData["pelvis"], "Icon": icons["Pelvis & Hips"]},
-            {"Title": "Lower Extremities", "Data": analysisData["lower_extremities"], "Icon": icons["Lower Extremities"]},
-            {"Title": "Ankles & Feet", "Data": analysisData["ankles_feet"], "Icon": icons["Ankles & Feet"]},
-        }
-        
-        // Merge forward_head_posture into head_neck for display
-        if headNeck, ok := analysisData["head_neck"].(map[string]interface{}); ok {
-            if globalAlign, ok := analysisData["global_alignment"].(map[string]interface{}); ok {
-                if fhp, ok := globalAlign["forward_head_posture"]; ok {
-                    headNeck["forward_head_posture"] = fhp
-                }
-            }
-        }
-        // Calculate Posture Score (100 is perfect)
-        score := 100
-        for _, region := range regions {
-            dataMap, ok := region["Data"].(map[string]interface{})
-            if !ok { continue }
-            
-            for _, v := range dataMap {
-                 if metric, ok := v.(map[string]interface{}); ok {
-                     sev, _ := metric["severity"].(string)
-                     switch sev {
-                     case "severe":
-                         score -= 15
-                     case "moderate":
-                         score -= 10
-                     case "mild":
-                         score -= 5
-                     }
-                 }
-            }
-        }
-        if score < 0 { score = 0 }
-        analysisData["PostureScore"] = score
-
-        analysisData["Regions"] = regions
-
-        // Generate exercise recommendations
-        exercises := generateExerciseRecommendations(analysisData)
-        analysisData["Exercises"] = exercises
+		// Inject image paths relative to output folder
+		imagesMap := map[string]string{
+			"front": "front" + filepath.Ext(*frontPtr),
+			"left":  "left" + filepath.Ext(*leftPtr),
+			"right": "right" + filepath.Ext(*rightPtr),
+			"back":  "back" + filepath.Ext(*backPtr),
+		}
+		analysisData["input_images"] = imagesMap
+		if usedProfile != "" {
+			analysisData["analysis_profile"] = usedProfile
+		}
+		if len(poseResults) > 0 {
+			analysisData["pose_landmarks"] = poseLandmarksForReport(poseResults)
+		}
+
+		// Helper string maps for icons
+		icons := map[string]string{
+			"Head & Neck":          "M16 7a4 4 0 11-8 0 4 4 0 018 0zM12 14a7 7 0 00-7 7h14a7 7 0 00-7-7z",
+			"Shoulders & Scapulae": "M19 11H5m14 0a2 2 0 012 2v6a2 2 0 01-2 2H5a2 2 0 01-2-2v-6a2 2 0 012-2m14 0V9a2 2 0 00-2-2M5 11V9a2 2 0 012-2m0 0V5a2 2 0 012-2h6a2 2 0 012 2v2M7 7h10",
+			"Spine":                "M4 6h16M4 10h16M4 14h16M4 18h16",
+			"Pelvis & Hips":        "M12 6V4m0 2a2 2 0 100 4m0-4a2 2 0 110 4m-6 8a2 2 0 100-4m0 4a2 2 0 110-4m0 4v2m0-6V4m6 6v10m6-2a2 2 0 100-4m0 4a2 2 0 110-4m0 4v2m0-6V4",
+			"Lower Extremities":    "M13.828 10.172a4 4 0 00-5.656 0l-4 4a4 4 0 105.656 5.656l1.102-1.101m-.758-4.899a4 4 0 005.656 0l4-4a4 4 0 00-5.656-5.656l-1.1 1.1",
+			"Ankles & Feet":        "M3.055 11H5a2 2 0 012 2v1a2 2 0 002 2 2 2 0 012 2v2.945M8 3.935V5.5A2.5 2.5 0 0010.5 8h.5a2 2 0 012 2 2 2 0 104 0 2 2 0 012-2h1.064M15 20.488V18a2 2 0 012-2h3.064",
+		}
+
+		// Construct Regions list manually
+		regions := []map[string]interface{}{
+			{"Title": "Head & Neck", "Data": analysisData["head_neck"], "Icon": icons["Head & Neck"]},
+			{"Title": "Shoulders & Scapulae", "Data": analysisData["shoulders"], "Icon": icons["Shoulders & Scapulae"]},
+			{"Title": "Spine", "Data": analysisData["spine"], "Icon": icons["Spine"]},
+			{"Title": "Pelvis & Hips", "Data": analysisData["pelvis"], "Icon": icons["Pelvis & Hips"]},
+			{"Title": "Lower Extremities", "Data": analysisData["lower_extremities"], "Icon": icons["Lower Extremities"]},
+			{"Title": "Ankles & Feet", "Data": analysisData["ankles_feet"], "Icon": icons["Ankles & Feet"]},
+		}
+
+		// Merge forward_head_posture into head_neck for display
+		if headNeck, ok := analysisData["head_neck"].(map[string]interface{}); ok {
+			if globalAlign, ok := analysisData["global_alignment"].(map[string]interface{}); ok {
+				if fhp, ok := globalAlign["forward_head_posture"]; ok {
+					headNeck["forward_head_posture"] = fhp
+				}
+			}
+		}
+		if len(poseResults) > 0 {
+			crossCheckPoseAgainstRegions(poseResults, analysisData)
+		}
+
+		// Calculate per-region domain scores and a composite PostureScore
+		// as their weighted mean, rather than one flat score summed
+		// across every metric regardless of region.
+		domainScores, domainDetails, compositeScore := domainscore.Score(regions, domainscore.ParseWeights(domainscore.WeightsEnv(*domainWeightsPtr)))
+		analysisData["PostureScore"] = compositeScore
+		analysisData["DomainScores"] = domainScores
+		analysisData["DomainDetails"] = domainDetails
+
+		analysisData["Regions"] = regions
+
+		if scoresJSON, err := json.MarshalIndent(map[string]interface{}{
+			"posture_score":  compositeScore,
+			"domain_scores":  domainScores,
+			"domain_details": domainDetails,
+		}, "", "  "); err == nil {
+			os.WriteFile(filepath.Join(outputDir, "scores.json"), scoresJSON, 0644)
+		}
+
+		// Generate exercise recommendations from the rule catalog
+		catalog, err := exercises.Load(exercises.Dir(*exercisesDirPtr))
+		if err != nil {
+			log.Printf("Warning: could not load exercise catalog: %v", err)
+			catalog = &exercises.Catalog{}
+		}
+		analysisData["Exercises"] = catalog.Recommend(analysisData)
 
 		generateHTMLReport(outputDir, analysisData, templateFile)
+
+		if *pdfPtr {
+			generatePDFReport(outputDir, analysisData, templateFile, *pdfPageSizePtr, *pdfLocalePtr)
+		}
+
+		indexAnalysis(outputBase, timestamp, analysisData)
 	}
 }
 
-func runGemini(ctx context.Context, promptText, frontPath, leftPath, rightPath, backPath string) string {
+func runGemini(ctx context.Context, promptText, frontPath, leftPath, rightPath, backPath string) (string, error) {
 	apiKey := os.Getenv("GOOGLE_API_KEY")
 	if apiKey == "" {
 		apiKey = os.Getenv("GEMINI_API_KEY")
 	}
 	if apiKey == "" {
-		log.Fatal("Error: GOOGLE_API_KEY not set")
+		return "", fmt.Errorf("GOOGLE_API_KEY not set")
 	}
 
 	modelName := os.Getenv("GEMINI_MODEL")
@@ -235,7 +322,7 @@ func runGemini(ctx context.Context, promptText, frontPath, leftPath, rightPath,
 
 	client, err := genai.NewClient(ctx, option.WithAPIKey(apiKey))
 	if err != nil {
-		log.Fatalf("Error creating Gemini client: %v", err)
+		return "", fmt.Errorf("creating Gemini client: %w", err)
 	}
 	defer client.Close()
 
@@ -270,7 +357,7 @@ func runGemini(ctx context.Context, promptText, frontPath, leftPath, rightPath,
 	fmt.Printf("Sending request to Gemini (%s)...\n", modelName)
 	resp, err := model.GenerateContent(ctx, parts...)
 	if err != nil {
-		log.Fatalf("Error generating content: %v", err)
+		return "", fmt.Errorf("generating content: %w", err)
 	}
 
 	var result strings.Builder
@@ -283,13 +370,13 @@ func runGemini(ctx context.Context, promptText, frontPath, leftPath, rightPath,
 			}
 		}
 	}
-	return result.String()
+	return result.String(), nil
 }
 
-func runOpenAI(ctx context.Context, promptText, frontPath, leftPath, rightPath, backPath string) string {
+func runOpenAI(ctx context.Context, promptText, frontPath, leftPath, rightPath, backPath string) (string, error) {
 	apiKey := os.Getenv("OPENAI_API_KEY")
 	if apiKey == "" {
-		log.Fatal("Error: OPENAI_API_KEY not set")
+		return "", fmt.Errorf("OPENAI_API_KEY not set")
 	}
 
 	modelName := os.Getenv("OPENAI_MODEL")
@@ -347,20 +434,303 @@ func runOpenAI(ctx context.Context, promptText, frontPath, leftPath, rightPath,
 		},
 	})
 	if err != nil {
-		log.Fatalf("Error generating content: %v", err)
+		return "", fmt.Errorf("generating content: %w", err)
 	}
 
 	if len(resp.Choices) == 0 {
-		log.Fatal("No response from OpenAI")
+		return "", fmt.Errorf("no response from OpenAI")
+	}
+	return resp.Choices[0].Message.Content, nil
+}
+
+const ollamaMaxRepairAttempts = 3
+
+type ollamaChatRequest struct {
+	Model    string              `json:"model"`
+	Messages []ollamaChatMessage `json:"messages"`
+	Stream   bool                `json:"stream"`
+	Format   string              `json:"format,omitempty"`
+}
+
+type ollamaChatMessage struct {
+	Role    string   `json:"role"`
+	Content string   `json:"content"`
+	Images  []string `json:"images,omitempty"`
+}
+
+type ollamaChatResponse struct {
+	Message ollamaChatMessage `json:"message"`
+}
+
+// runOllama hits a local Ollama server with a vision-capable model
+// (e.g. llava, llama3.2-vision). Ollama doesn't enforce a JSON schema
+// the way Gemini/OpenAI's structured-output modes do, so this sends an
+// explicit example shaped like buildPostureSchema and validates the
+// reply, re-prompting with the parse error appended up to
+// ollamaMaxRepairAttempts times. This gives a fully offline path for
+// patient data that cannot leave the premises.
+func runOllama(ctx context.Context, promptText, frontPath, leftPath, rightPath, backPath string) (string, error) {
+	baseURL := os.Getenv("OLLAMA_BASE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:11434"
+	}
+	modelName := os.Getenv("OLLAMA_MODEL")
+	if modelName == "" {
+		modelName = "llava"
+	}
+
+	exampleJSON, err := json.MarshalIndent(schemaExample(buildPostureSchema()), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("building schema example: %w", err)
+	}
+
+	var images []string
+	addImage := func(path string) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Printf("Warning: could not read image %s: %v", path, err)
+			return
+		}
+		images = append(images, base64.StdEncoding.EncodeToString(data))
+	}
+	addImage(frontPath)
+	addImage(leftPath)
+	addImage(rightPath)
+	addImage(backPath)
+
+	prompt := fmt.Sprintf("%s\n\nRespond with ONLY JSON matching this exact shape:\n%s", promptText, exampleJSON)
+
+	var lastErr error
+	for attempt := 1; attempt <= ollamaMaxRepairAttempts; attempt++ {
+		fmt.Printf("Sending request to Ollama (%s), attempt %d/%d...\n", modelName, attempt, ollamaMaxRepairAttempts)
+		resultText, err := ollamaChat(ctx, baseURL, modelName, prompt, images)
+		if err != nil {
+			return "", fmt.Errorf("calling Ollama: %w", err)
+		}
+
+		var v map[string]interface{}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(resultText)), &v); err == nil {
+			return resultText, nil
+		} else {
+			lastErr = err
+			prompt = fmt.Sprintf("%s\n\nYour previous reply was not valid JSON (%v):\n%s\n\nReply again with ONLY corrected JSON matching the shape above.", promptText, err, resultText)
+		}
+	}
+
+	return "", fmt.Errorf("Ollama never returned valid JSON after %d attempts: %w", ollamaMaxRepairAttempts, lastErr)
+}
+
+func ollamaChat(ctx context.Context, baseURL, modelName, prompt string, images []string) (string, error) {
+	reqBody, err := json.Marshal(ollamaChatRequest{
+		Model: modelName,
+		Messages: []ollamaChatMessage{
+			{Role: "user", Content: prompt, Images: images},
+		},
+		Stream: false,
+		Format: "json",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/api/chat", bytes.NewReader(reqBody))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Ollama request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var parsed ollamaChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", fmt.Errorf("parsing Ollama response: %w", err)
+	}
+	return parsed.Message.Content, nil
+}
+
+// schemaExample walks a genai.Schema and produces a placeholder
+// map/slice/value tree of the same shape, so it can be embedded in a
+// prompt as a concrete example for models (like Ollama's) that don't
+// support a structured-output mode.
+func schemaExample(schema *genai.Schema) interface{} {
+	switch schema.Type {
+	case genai.TypeObject:
+		obj := make(map[string]interface{}, len(schema.Properties))
+		for name, prop := range schema.Properties {
+			obj[name] = schemaExample(prop)
+		}
+		return obj
+	case genai.TypeArray:
+		return []interface{}{schemaExample(schema.Items)}
+	case genai.TypeString:
+		if len(schema.Enum) > 0 {
+			return schema.Enum[0]
+		}
+		return "string"
+	case genai.TypeNumber:
+		return 0
+	case genai.TypeBoolean:
+		return false
+	default:
+		return nil
 	}
-	return resp.Choices[0].Message.Content
 }
 
-type Exercise struct {
-	Name        string
-	Description string
-	Frequency   string
-	Purpose     string
+// runBackend dispatches to a pluggable backend binary looked up by
+// name under backendDir, per the PostureBackend gRPC contract in
+// pkg/backend. Unlike runGemini/runOpenAI it schema-validates nothing
+// itself; the backend is responsible for honoring the schema it was
+// sent.
+func runBackend(ctx context.Context, backendDir, name, promptText, frontPath, leftPath, rightPath, backPath, outputDir string) (string, error) {
+	b, err := backend.Load(ctx, backendDir, name)
+	if err != nil {
+		return "", fmt.Errorf("loading backend %q: %w", name, err)
+	}
+	defer b.Close()
+
+	fmt.Printf("Sending request to backend %q...\n", name)
+	return analyzeViaBackend(ctx, b, promptText, frontPath, leftPath, rightPath, backPath, outputDir)
+}
+
+// runGRPCBackend dials a backend that is already running at
+// GRPC_BACKEND_ADDR (AI_PROVIDER=grpc), rather than spawning one by
+// name under --backend-path. This is the path for benchmarking or
+// swapping in a self-hosted vision model (LLaVA, Qwen-VL, a remote
+// Ollama, ...) that this process doesn't own the lifecycle of.
+func runGRPCBackend(ctx context.Context, promptText, frontPath, leftPath, rightPath, backPath, outputDir string) (string, error) {
+	addr := os.Getenv("GRPC_BACKEND_ADDR")
+	if addr == "" {
+		return "", fmt.Errorf("GRPC_BACKEND_ADDR not set (required for AI_PROVIDER=grpc)")
+	}
+
+	b, err := backend.Connect(ctx, addr)
+	if err != nil {
+		return "", fmt.Errorf("connecting to gRPC backend at %s: %w", addr, err)
+	}
+	defer b.Close()
+
+	fmt.Printf("Sending request to gRPC backend at %s...\n", addr)
+	return analyzeViaBackend(ctx, b, promptText, frontPath, leftPath, rightPath, backPath, outputDir)
+}
+
+// analyzeViaBackend builds the image parts and schema shared by every
+// PostureBackend call site and dispatches through the interface, so
+// buildPostureSchema stays the single source of truth for every
+// provider, in-tree or pluggable. Per-field confidences reported by
+// the backend are saved alongside analysis.json rather than merely
+// logged, so a low-confidence field is something the report (and
+// whoever's reading it) can actually see.
+func analyzeViaBackend(ctx context.Context, b backend.PostureBackend, promptText, frontPath, leftPath, rightPath, backPath, outputDir string) (string, error) {
+	schemaJSON, err := json.Marshal(buildPostureSchema())
+	if err != nil {
+		return "", fmt.Errorf("marshaling schema: %w", err)
+	}
+
+	var images []backend.Image
+	addImage := func(label, path string) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Printf("Warning: could not read %s image %s: %v", label, path, err)
+			return
+		}
+		mimeType := "image/jpeg"
+		if strings.HasSuffix(strings.ToLower(path), ".png") {
+			mimeType = "image/png"
+		}
+		images = append(images, backend.Image{Label: label, MimeType: mimeType, Data: data})
+	}
+	addImage("Front View", frontPath)
+	addImage("Left Side View", leftPath)
+	addImage("Right Side View", rightPath)
+	addImage("Back View", backPath)
+
+	resultText, confidences, err := b.Analyze(ctx, promptText, images, string(schemaJSON))
+	if err != nil {
+		return "", fmt.Errorf("calling backend: %w", err)
+	}
+	if len(confidences) > 0 {
+		log.Printf("Backend reported %d field confidence(s)", len(confidences))
+		if confJSON, err := json.MarshalIndent(confidences, "", "  "); err == nil {
+			if err := os.WriteFile(filepath.Join(outputDir, "backend_confidences.json"), confJSON, 0644); err != nil {
+				log.Printf("Warning: could not save backend confidences: %v", err)
+			}
+		}
+	}
+	return resultText, nil
+}
+
+// runProfileChain tries each profile in order, applying its
+// model/temperature overrides via env vars before dispatching to the
+// matching provider. A profile is considered to have failed if the
+// call itself errored (network failure, rate limit, missing API key,
+// ...) or its response isn't parseable JSON; either way the chain
+// moves on to the next profile, and the caller is told which profile
+// ultimately produced the result.
+func runProfileChain(ctx context.Context, chain []config.Profile, promptText, frontPath, leftPath, rightPath, backPath, backendDir, outputDir string) (string, string) {
+	for i, p := range chain {
+		applyProfileOverrides(p)
+
+		fmt.Printf("Trying profile %q (provider=%s, model=%s)...\n", p.Name, p.Provider, p.Model)
+
+		var resultText string
+		var err error
+		switch p.Provider {
+		case "openai":
+			resultText, err = runOpenAI(ctx, promptText, frontPath, leftPath, rightPath, backPath)
+		case "gemini":
+			resultText, err = runGemini(ctx, promptText, frontPath, leftPath, rightPath, backPath)
+		case "ollama":
+			resultText, err = runOllama(ctx, promptText, frontPath, leftPath, rightPath, backPath)
+		case "grpc":
+			resultText, err = runGRPCBackend(ctx, promptText, frontPath, leftPath, rightPath, backPath, outputDir)
+		default:
+			resultText, err = runBackend(ctx, backendDir, p.Provider, promptText, frontPath, leftPath, rightPath, backPath, outputDir)
+		}
+
+		if err != nil {
+			log.Printf("Profile %q failed: %v", p.Name, err)
+			if i == len(chain)-1 {
+				return "", p.Name
+			}
+			continue
+		}
+
+		if isValidJSON(resultText) {
+			return resultText, p.Name
+		}
+
+		log.Printf("Profile %q returned invalid JSON", p.Name)
+		if i == len(chain)-1 {
+			return resultText, p.Name
+		}
+	}
+	return "", ""
+}
+
+func applyProfileOverrides(p config.Profile) {
+	switch p.Provider {
+	case "openai":
+		if p.Model != "" {
+			os.Setenv("OPENAI_MODEL", p.Model)
+		}
+	case "gemini":
+		if p.Model != "" {
+			os.Setenv("GEMINI_MODEL", p.Model)
+		}
+	}
+}
+
+func isValidJSON(s string) bool {
+	cleanJson := strings.TrimSpace(s)
+	cleanJson = strings.TrimPrefix(cleanJson, "```json")
+	cleanJson = strings.TrimPrefix(cleanJson, "```")
+	cleanJson = strings.TrimSuffix(cleanJson, "```")
+	var v map[string]interface{}
+	return json.Unmarshal([]byte(cleanJson), &v) == nil
 }
 
 func buildPostureSchema() *genai.Schema {
@@ -398,14 +768,14 @@ func buildPostureSchema() *genai.Schema {
 				},
 				Required: []string{"front_detected", "right_detected", "left_detected", "back_detected"},
 			},
-			"clinical_reasoning":   {Type: genai.TypeString},
-			"head_neck":            regionSchema("craniovertebral_angle", "lateral_head_tilt", "head_rotation"),
-			"shoulders":            regionSchema("shoulder_height_delta", "shoulder_protraction", "scapular_winging"),
-			"spine":                regionSchema("thoracic_kyphosis", "lumbar_lordosis", "lateral_deviation"),
-			"pelvis":               regionSchema("pelvic_tilt", "pelvic_obliquity", "pelvic_rotation"),
-			"lower_extremities":    regionSchema("knee_valgus_varus", "knee_hyperextension", "q_angle"),
-			"ankles_feet":          regionSchema("foot_progression_angle", "ankle_pronation", "arch_height"),
-			"global_alignment":     regionSchema("plumb_line_deviation", "forward_head_posture"),
+			"clinical_reasoning": {Type: genai.TypeString},
+			"head_neck":          regionSchema("craniovertebral_angle", "lateral_head_tilt", "head_rotation"),
+			"shoulders":          regionSchema("shoulder_height_delta", "shoulder_protraction", "scapular_winging"),
+			"spine":              regionSchema("thoracic_kyphosis", "lumbar_lordosis", "lateral_deviation"),
+			"pelvis":             regionSchema("pelvic_tilt", "pelvic_obliquity", "pelvic_rotation"),
+			"lower_extremities":  regionSchema("knee_valgus_varus", "knee_hyperextension", "q_angle"),
+			"ankles_feet":        regionSchema("foot_progression_angle", "ankle_pronation", "arch_height"),
+			"global_alignment":   regionSchema("plumb_line_deviation", "forward_head_posture"),
 			"clinical_inference": {
 				Type: genai.TypeObject,
 				Properties: map[string]*genai.Schema{
@@ -417,15 +787,15 @@ func buildPostureSchema() *genai.Schema {
 						},
 						Required: []string{"likely_tight", "likely_weak"},
 					},
-					"compensation_chain":     {Type: genai.TypeString},
-					"priority_areas":         {Type: genai.TypeArray, Items: &genai.Schema{Type: genai.TypeString}},
-					"clinical_implications":  {Type: genai.TypeString},
+					"compensation_chain":    {Type: genai.TypeString},
+					"priority_areas":        {Type: genai.TypeArray, Items: &genai.Schema{Type: genai.TypeString}},
+					"clinical_implications": {Type: genai.TypeString},
 				},
 				Required: []string{"muscle_imbalances", "compensation_chain", "priority_areas", "clinical_implications"},
 			},
-			"image_quality_notes":  {Type: genai.TypeString},
-			"overall_confidence":   {Type: genai.TypeNumber},
-			"back_view_provided":   {Type: genai.TypeBoolean},
+			"image_quality_notes": {Type: genai.TypeString},
+			"overall_confidence":  {Type: genai.TypeNumber},
+			"back_view_provided":  {Type: genai.TypeBoolean},
 			"annotations": {
 				Type: genai.TypeArray,
 				Items: &genai.Schema{
@@ -445,94 +815,10 @@ func buildPostureSchema() *genai.Schema {
 	}
 }
 
-func generateExerciseRecommendations(analysisData map[string]interface{}) []Exercise {
-	exercises := []Exercise{}
-
-	// Check for forward head posture
-	if globalAlignment, ok := analysisData["global_alignment"].(map[string]interface{}); ok {
-		if fhp, ok := globalAlignment["forward_head_posture"].(map[string]interface{}); ok {
-			if severity, _ := fhp["severity"].(string); severity == "moderate" || severity == "severe" {
-				exercises = append(exercises, Exercise{
-					Name:        "Chin Tucks",
-					Description: "Gently retract chin backward (like making a double chin), hold for 5 seconds. Keep eyes level.",
-					Frequency:   "3 sets of 10 reps, 2x daily",
-					Purpose:     "Strengthens deep neck flexors and reduces forward head posture",
-				})
-			}
-		}
-	}
-
-	// Check for thoracic kyphosis
-	if spine, ok := analysisData["spine"].(map[string]interface{}); ok {
-		if kyphosis, ok := spine["thoracic_kyphosis"].(map[string]interface{}); ok {
-			if severity, _ := kyphosis["severity"].(string); severity == "moderate" || severity == "severe" {
-				exercises = append(exercises, Exercise{
-					Name:        "Thoracic Extensions",
-					Description: "Place hands behind head, gently extend upper back over a foam roller or rolled towel. Hold 30 seconds.",
-					Frequency:   "3-5 repetitions, 1-2x daily",
-					Purpose:     "Improves thoracic spine mobility and reduces excessive kyphosis",
-				})
-			}
-		}
-	}
-
-	// Check for shoulder protraction
-	if shoulders, ok := analysisData["shoulders"].(map[string]interface{}); ok {
-		if protraction, ok := shoulders["shoulder_protraction"].(map[string]interface{}); ok {
-			if severity, _ := protraction["severity"].(string); severity == "moderate" || severity == "severe" {
-				exercises = append(exercises, Exercise{
-					Name:        "Scapular Retractions",
-					Description: "Squeeze shoulder blades together as if holding a pencil between them. Hold for 5 seconds.",
-					Frequency:   "3 sets of 15 reps, 2x daily",
-					Purpose:     "Strengthens rhomboids and middle trapezius to improve shoulder posture",
-				})
-			}
-		}
-	}
-
-	// Check for lumbar lordosis
-	if spine, ok := analysisData["spine"].(map[string]interface{}); ok {
-		if lordosis, ok := spine["lumbar_lordosis"].(map[string]interface{}); ok {
-			if severity, _ := lordosis["severity"].(string); severity == "moderate" || severity == "severe" {
-				exercises = append(exercises, Exercise{
-					Name:        "Pelvic Tilts",
-					Description: "Lie on back with knees bent. Flatten lower back against floor by tilting pelvis. Hold 5 seconds.",
-					Frequency:   "3 sets of 12 reps, 1-2x daily",
-					Purpose:     "Activates core muscles and normalizes lumbar curve",
-				})
-			}
-		}
-	}
-
-	// Check for knee hyperextension
-	if lowerExt, ok := analysisData["lower_extremities"].(map[string]interface{}); ok {
-		if hyperext, ok := lowerExt["knee_hyperextension"].(map[string]interface{}); ok {
-			if severity, _ := hyperext["severity"].(string); severity == "mild" || severity == "moderate" {
-				exercises = append(exercises, Exercise{
-					Name:        "Quadriceps Strengthening",
-					Description: "Seated leg extensions with slight knee bend. Focus on controlled movement without locking knees.",
-					Frequency:   "3 sets of 10 reps, 3x weekly",
-					Purpose:     "Improves knee control and reduces hyperextension tendency",
-				})
-			}
-		}
-	}
-
-	// Add general postural awareness exercise if multiple issues
-	if len(exercises) >= 3 {
-		exercises = append(exercises, Exercise{
-			Name:        "Postural Awareness Practice",
-			Description: "Stand against wall with heels, buttocks, shoulders, and head touching. Hold 30 seconds while breathing normally.",
-			Frequency:   "2-3 times daily",
-			Purpose:     "Develops kinesthetic awareness of optimal alignment",
-		})
-	}
-
-	return exercises
-}
-
-func generateHTMLReport(outputDir string, data map[string]interface{}, tmplPath string) {
-	tmplFuncs := template.FuncMap{
+// reportTemplateFuncs is the FuncMap shared by report.html and, for
+// chromedp to print, the intermediate HTML rendered for report.pdf.
+func reportTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
 		"dict": func(values ...interface{}) (map[string]interface{}, error) {
 			if len(values)%2 != 0 {
 				return nil, fmt.Errorf("invalid dict call")
@@ -570,8 +856,10 @@ func generateHTMLReport(outputDir string, data map[string]interface{}, tmplPath
 			return float64(i)
 		},
 	}
+}
 
-	tmpl, err := template.New(filepath.Base(tmplPath)).Funcs(tmplFuncs).ParseFiles(tmplPath)
+func generateHTMLReport(outputDir string, data map[string]interface{}, tmplPath string) {
+	tmpl, err := template.New(filepath.Base(tmplPath)).Funcs(reportTemplateFuncs()).ParseFiles(tmplPath)
 	if err != nil {
 		log.Printf("Warning: Could not parse HTML template: %v", err)
 		return
@@ -591,3 +879,147 @@ func generateHTMLReport(outputDir string, data map[string]interface{}, tmplPath
 		fmt.Printf("Report saved to: %s\n", reportPath)
 	}
 }
+
+// pdfPageSizes maps a --pdf-page-size name to chromedp's print
+// dimensions, in inches.
+var pdfPageSizes = map[string][2]float64{
+	"A4":     {8.27, 11.69},
+	"Letter": {8.5, 11},
+	"Legal":  {8.5, 14},
+}
+
+// generatePDFReport renders the same template data generateHTMLReport
+// does into report.pdf: a paginated, self-contained document with the
+// front/left/right/back images inlined as base64 data URIs (not
+// relative paths) so the file is portable on its own - suitable for
+// handing to a patient or filing in an EMR. It reuses html/template to
+// produce the page, then drives a headless Chromium instance (via
+// chromedp) to print it, since that reproduces the same CSS layout as
+// report.html rather than re-implementing it in a PDF-native renderer.
+//
+// If no Chrome/Chromium binary can be found, this logs a clear
+// warning and returns without creating report.pdf - a missing
+// renderer shouldn't fail the rest of the pipeline.
+func generatePDFReport(outputDir string, data map[string]interface{}, tmplPath, pageSize, locale string) {
+	chromePath, err := findChrome()
+	if err != nil {
+		log.Printf("Warning: skipping PDF report, no Chrome/Chromium binary found: %v", err)
+		return
+	}
+
+	dims, ok := pdfPageSizes[pageSize]
+	if !ok {
+		log.Printf("Warning: unknown --pdf-page-size %q, defaulting to A4", pageSize)
+		dims = pdfPageSizes["A4"]
+	}
+
+	pdfData := inlineImages(data, outputDir)
+	pdfData["Locale"] = locale
+
+	tmpl, err := template.New(filepath.Base(tmplPath)).Funcs(reportTemplateFuncs()).ParseFiles(tmplPath)
+	if err != nil {
+		log.Printf("Warning: Could not parse HTML template for PDF: %v", err)
+		return
+	}
+	var htmlBuf bytes.Buffer
+	if err := tmpl.Execute(&htmlBuf, pdfData); err != nil {
+		log.Printf("Warning: Could not render HTML for PDF: %v", err)
+		return
+	}
+
+	// chromedp prints a loaded page, so the rendered HTML needs to
+	// exist as a file it can navigate to; removed once printing is done.
+	htmlPath := filepath.Join(outputDir, ".report_pdf_source.html")
+	if err := os.WriteFile(htmlPath, htmlBuf.Bytes(), 0644); err != nil {
+		log.Printf("Warning: Could not write intermediate HTML for PDF: %v", err)
+		return
+	}
+	defer os.Remove(htmlPath)
+
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(),
+		append(chromedp.DefaultExecAllocatorOptions[:], chromedp.ExecPath(chromePath))...)
+	defer allocCancel()
+	taskCtx, taskCancel := chromedp.NewContext(allocCtx)
+	defer taskCancel()
+	ctx, cancel := context.WithTimeout(taskCtx, 60*time.Second)
+	defer cancel()
+
+	var pdfBytes []byte
+	err = chromedp.Run(ctx,
+		chromedp.Navigate("file://"+htmlPath),
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			buf, _, err := page.PrintToPDF().
+				WithPaperWidth(dims[0]).
+				WithPaperHeight(dims[1]).
+				WithPrintBackground(true).
+				Do(ctx)
+			if err != nil {
+				return err
+			}
+			pdfBytes = buf
+			return nil
+		}),
+	)
+	if err != nil {
+		log.Printf("Warning: PDF rendering failed: %v", err)
+		return
+	}
+
+	pdfPath := filepath.Join(outputDir, "report.pdf")
+	if err := os.WriteFile(pdfPath, pdfBytes, 0644); err != nil {
+		log.Printf("Warning: Could not write %s: %v", pdfPath, err)
+		return
+	}
+	fmt.Printf("PDF report saved to: %s\n", pdfPath)
+}
+
+// findChrome looks for a Chrome/Chromium binary on PATH for chromedp
+// to drive; CHROME_PATH overrides the search.
+func findChrome() (string, error) {
+	if v := os.Getenv("CHROME_PATH"); v != "" {
+		return v, nil
+	}
+	for _, name := range []string{"google-chrome", "chromium", "chromium-browser", "chrome"} {
+		if path, err := exec.LookPath(name); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("none of google-chrome/chromium/chromium-browser/chrome found on PATH")
+}
+
+// inlineImages returns a shallow copy of data with input_images'
+// relative filenames replaced by base64 data URIs read from
+// outputDir, so report.pdf doesn't depend on sibling image files.
+func inlineImages(data map[string]interface{}, outputDir string) map[string]interface{} {
+	out := make(map[string]interface{}, len(data)+1)
+	for k, v := range data {
+		out[k] = v
+	}
+
+	images, ok := data["input_images"].(map[string]string)
+	if !ok {
+		return out
+	}
+	inlined := make(map[string]string, len(images))
+	for view, rel := range images {
+		b, err := os.ReadFile(filepath.Join(outputDir, rel))
+		if err != nil {
+			log.Printf("Warning: could not inline %s image for PDF: %v", view, err)
+			continue
+		}
+		inlined[view] = "data:" + imageMIMEType(rel) + ";base64," + base64.StdEncoding.EncodeToString(b)
+	}
+	out["input_images"] = inlined
+	return out
+}
+
+func imageMIMEType(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".png":
+		return "image/png"
+	case ".webp":
+		return "image/webp"
+	default:
+		return "image/jpeg"
+	}
+}
@@ -1,8 +1,14 @@
+// This binary (main_hybrid.go/serve_hybrid.go) is the canonical entry
+// point going forward: it's where pose grounding, deterministic
+// scoring, and the video pipeline (chunk1-2, chunk2-1, chunk2-4,
+// chunk2-5) have landed. main.go/generate_report.go still own domain
+// radar scoring, PDF export, and --compare, which haven't been ported
+// here yet - that's tracked as a follow-up request rather than forked
+// silently again.
 package main
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -12,13 +18,18 @@ import (
 	"os/exec"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
-	"github.com/google/generative-ai-go/genai"
 	"github.com/joho/godotenv"
-	openai "github.com/sashabaranov/go-openai"
-	"google.golang.org/api/option"
+
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/aggregate"
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/llm"
+	_ "github.com/pranjal-lnct/PostureAnalysis/pkg/llm/gemini"
+	_ "github.com/pranjal-lnct/PostureAnalysis/pkg/llm/openai"
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/poseextractor"
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/scoring"
 )
 
 type Metric struct {
@@ -28,12 +39,12 @@ type Metric struct {
 }
 
 type PoseMetrics struct {
-	CraniovertebralAngle  *Metric `json:"craniovertebral_angle,omitempty"`
-	ForwardHeadPosture    *Metric `json:"forward_head_posture,omitempty"`
-	ShoulderHeightDelta   *Metric `json:"shoulder_height_delta,omitempty"`
-	ThoracicKyphosis      *Metric `json:"thoracic_kyphosis,omitempty"`
-	KneeValgusVarus       *Metric `json:"knee_valgus_varus,omitempty"`
-	FootProgressionAngle  *Metric `json:"foot_progression_angle,omitempty"`
+	CraniovertebralAngle *Metric `json:"craniovertebral_angle,omitempty"`
+	ForwardHeadPosture   *Metric `json:"forward_head_posture,omitempty"`
+	ShoulderHeightDelta  *Metric `json:"shoulder_height_delta,omitempty"`
+	ThoracicKyphosis     *Metric `json:"thoracic_kyphosis,omitempty"`
+	KneeValgusVarus      *Metric `json:"knee_valgus_varus,omitempty"`
+	FootProgressionAngle *Metric `json:"foot_progression_angle,omitempty"`
 }
 
 func main() {
@@ -41,232 +52,296 @@ func main() {
 		log.Println("Warning: No .env file found")
 	}
 
-	frontPtr := flag.String("front", "", "Path to front view image")
-	leftPtr := flag.String("left", "", "Path to left view image")
-	rightPtr := flag.String("right", "", "Path to right view image")
-	backPtr := flag.String("back", "", "Path to back view image")
+	// `serve` is a subcommand (mirrors main.go's serve dispatch) so it
+	// can own its own flag set (--address, --upload-limit-mb) without
+	// colliding with the one-shot CLI flags below.
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		runHybridServe(os.Args[2:])
+		return
+	}
+
+	frontPtr := flag.String("front", "", "Path to front view image, or a directory of frame images for that view")
+	leftPtr := flag.String("left", "", "Path to left view image, or a directory of frame images for that view")
+	rightPtr := flag.String("right", "", "Path to right view image, or a directory of frame images for that view")
+	backPtr := flag.String("back", "", "Path to back view image, or a directory of frame images for that view")
 	heightPtr := flag.Float64("height", 0, "User height in cm (optional, for calibration)")
-	providerPtr := flag.String("provider", "gemini", "AI provider: gemini or openai")
+	providerPtr := flag.String("provider", "gemini", "AI provider: gemini, openai, or openai-compatible")
+	videoPtr := flag.String("video", "", "Path to a single video file to sample frames from (used for all four views; overrides --front/--left/--right/--back)")
+	videoFPSPtr := flag.Float64("video-fps", 1, "Frames per second to sample from --video")
+	minVisibilityPtr := flag.Float64("min-visibility", aggregate.DefaultMinVisibility, "Minimum MediaPipe landmark visibility for a frame to be included in aggregation")
+	thresholdsPtr := flag.String("thresholds", "", "Path to a thresholds.yaml of severity cutoffs (overrides THRESHOLDS_FILE, default thresholds.yaml)")
 	flag.Parse()
 
-	if *frontPtr == "" || *leftPtr == "" || *rightPtr == "" || *backPtr == "" {
-		log.Fatal("Error: All 4 views required (--front, --left, --right, --back)")
+	thresholds, err := scoring.Load(scoring.Path(*thresholdsPtr))
+	if err != nil {
+		log.Fatalf("Error loading thresholds: %v", err)
+	}
+
+	if *videoPtr == "" && (*frontPtr == "" || *leftPtr == "" || *rightPtr == "" || *backPtr == "") {
+		log.Fatal("Error: All 4 views required (--front, --left, --right, --back), or --video")
 	}
 
 	// Create output directory first
 	outputDir := createOutputDir()
 
+	// The pose extractor server is spawned on first use and kept
+	// alive for the rest of this process; shut it down gracefully on
+	// exit instead of leaving it running.
+	defer poseextractor.Shutdown()
+
+	frontFrames, leftFrames, rightFrames, backFrames, err := resolveViewFrames(*videoPtr, *videoFPSPtr, *frontPtr, *leftPtr, *rightPtr, *backPtr, outputDir)
+	if err != nil {
+		log.Fatalf("Error resolving view frames: %v", err)
+	}
+
 	// Step 1: Extract pose metrics using MediaPipe
 	fmt.Println("Step 1: Extracting pose landmarks with MediaPipe...")
-	metrics, err := extractPoseMetrics(*frontPtr, *leftPtr, *rightPtr, *backPtr, *heightPtr, outputDir)
+	metrics, timeseriesJSON, err := extractPoseMetricsFrames(outputDir, *heightPtr, frontFrames, leftFrames, rightFrames, backFrames, *minVisibilityPtr)
 	if err != nil {
 		log.Fatalf("Error extracting pose: %v", err)
 	}
+	if err := os.WriteFile(filepath.Join(outputDir, "metrics_timeseries.json"), timeseriesJSON, 0644); err != nil {
+		log.Printf("Warning: could not save metrics_timeseries.json: %v", err)
+	}
 
 	fmt.Println("\n=== MEASURED METRICS (Deterministic) ===")
 	printMetrics(metrics)
 
-	// Step 2: Get LLM interpretation (with images + metrics)
+	// Score against clinical thresholds before the LLM ever sees the
+	// data, so the severities and total score are reproducible across
+	// runs instead of depending on whatever the model writes.
+	regionSeverities, deterministicScore, evidence := scoring.Score(toScoringMetrics(metrics), thresholds)
+	fmt.Printf("\n=== DETERMINISTIC SCORE (Rule Engine) ===\n%d/100\n", deterministicScore)
+
+	// Step 2: Get LLM interpretation (with images + metrics + the
+	// deterministic severities as ground truth). A representative
+	// still frame (the first one) is what gets sent to the LLM and
+	// embedded in the report, even when a view was measured from
+	// several frames.
+	frontPath, leftPath, rightPath, backPath := frontFrames[0], leftFrames[0], rightFrames[0], backFrames[0]
 	fmt.Println("\nStep 2: Getting clinical interpretation from LLM (with images + measured data)...")
-	interpretation := getLLMInterpretation(metrics, *providerPtr, *frontPtr, *leftPtr, *rightPtr, *backPtr)
+	interpretation := getLLMInterpretation(metrics, regionSeverities, *providerPtr, frontPath, leftPath, rightPath, backPath)
 
 	// Step 3: Save results
-	saveResults(outputDir, metrics, interpretation, *frontPtr, *leftPtr, *rightPtr, *backPtr)
+	saveResults(outputDir, metrics, interpretation, frontPath, leftPath, rightPath, backPath, timeseriesJSON, deterministicScore, evidence)
 
 	fmt.Printf("\n✓ Analysis complete! Results saved to: %s\n", outputDir)
 	fmt.Printf("  - analysis.json (combined data)\n")
 	fmt.Printf("  - report.html (visual report)\n")
 	fmt.Printf("  - metrics.json (measured values)\n")
+	fmt.Printf("  - metrics_timeseries.json (per-frame samples)\n")
+	fmt.Printf("  - evidence.json (deterministic severities and thresholds used)\n")
 	fmt.Printf("  - interpretation.json (LLM analysis)\n")
 	fmt.Printf("  - *_annotated.jpg (images with landmarks)\n")
 }
 
-func extractPoseMetrics(front, left, right, back string, height float64, outputDir string) (*PoseMetrics, error) {
-	// Find Python venv
-	venvPython := "./venv/bin/python3"
-	if _, err := os.Stat(venvPython); os.IsNotExist(err) {
-		venvPython = "python3"
+// toScoringMetrics converts main_hybrid.go's own PoseMetrics into
+// pkg/scoring's equivalent shape (see scoring.Metrics' doc comment for
+// why scoring keeps its own mirrored type instead of importing this
+// package).
+func toScoringMetrics(m *PoseMetrics) scoring.Metrics {
+	convert := func(metric *Metric) *scoring.Metric {
+		if metric == nil {
+			return nil
+		}
+		return &scoring.Metric{Value: metric.Value, Unit: metric.Unit, Confidence: metric.Confidence}
 	}
-
-	args := []string{"pose_extractor.py", front, left, right, back}
-	if height > 0 {
-		args = append(args, fmt.Sprintf("%.1f", height))
+	return scoring.Metrics{
+		CraniovertebralAngle: convert(m.CraniovertebralAngle),
+		ForwardHeadPosture:   convert(m.ForwardHeadPosture),
+		ShoulderHeightDelta:  convert(m.ShoulderHeightDelta),
+		ThoracicKyphosis:     convert(m.ThoracicKyphosis),
+		KneeValgusVarus:      convert(m.KneeValgusVarus),
+		FootProgressionAngle: convert(m.FootProgressionAngle),
 	}
-	// Add output directory for annotated images
-	args = append(args, outputDir)
+}
 
-	cmd := exec.Command(venvPython, args...)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return nil, fmt.Errorf("pose extraction failed: %v\n%s", err, output)
+// resolveViewFrames turns the --video or --front/--left/--right/--back
+// flags into one frame-path sequence per view. --video takes
+// precedence: its frames (sampled via ffmpeg) are used for all four
+// views, since a single camera can't capture four orientations at
+// once - useful for continuous-monitoring footage rather than the
+// four-photo intake flow. Otherwise each view flag is resolved
+// independently via resolveFrames.
+func resolveViewFrames(video string, videoFPS float64, front, left, right, back, outputDir string) (frontFrames, leftFrames, rightFrames, backFrames []string, err error) {
+	if video != "" {
+		frames, err := extractVideoFrames(video, videoFPS, outputDir)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		return frames, frames, frames, frames, nil
 	}
 
-	// Parse JSON output
-	var result struct {
-		Metrics PoseMetrics `json:"metrics"`
+	if frontFrames, err = resolveFrames(front); err != nil {
+		return nil, nil, nil, nil, err
 	}
-
-	// Filter out stderr logs
-	lines := strings.Split(string(output), "\n")
-	var jsonLines []string
-	inJSON := false
-	for _, line := range lines {
-		if strings.HasPrefix(line, "{") {
-			inJSON = true
-		}
-		if inJSON {
-			jsonLines = append(jsonLines, line)
-		}
+	if leftFrames, err = resolveFrames(left); err != nil {
+		return nil, nil, nil, nil, err
 	}
-
-	jsonStr := strings.Join(jsonLines, "\n")
-	if err := json.Unmarshal([]byte(jsonStr), &result); err != nil {
-		return nil, fmt.Errorf("failed to parse metrics: %v", err)
+	if rightFrames, err = resolveFrames(right); err != nil {
+		return nil, nil, nil, nil, err
 	}
-
-	return &result.Metrics, nil
+	if backFrames, err = resolveFrames(back); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return frontFrames, leftFrames, rightFrames, backFrames, nil
 }
 
-func getLLMInterpretation(metrics *PoseMetrics, provider string, frontPath, leftPath, rightPath, backPath string) string {
-	// Load prompt template
-	promptTemplate, err := os.ReadFile("prompt_hybrid.txt")
+// resolveFrames turns a --front/--left/--right/--back flag value into
+// an ordered sequence of frame image paths: the path itself if it's a
+// single file, or every image file inside it (sorted) if it's a
+// directory of frames sampled from a per-view clip.
+func resolveFrames(path string) ([]string, error) {
+	info, err := os.Stat(path)
 	if err != nil {
-		log.Fatalf("Error reading prompt: %v", err)
+		return nil, err
 	}
-
-	// Inject metrics into prompt
-	metricsJSON, _ := json.MarshalIndent(metrics, "", "  ")
-	prompt := strings.Replace(string(promptTemplate), "{METRICS_JSON}", string(metricsJSON), 1)
-
-	ctx := context.Background()
-
-	if provider == "openai" {
-		return runOpenAIHybrid(ctx, prompt, frontPath, leftPath, rightPath, backPath)
+	if !info.IsDir() {
+		return []string{path}, nil
 	}
-	return runGeminiHybrid(ctx, prompt, frontPath, leftPath, rightPath, backPath)
-}
 
-func runGeminiHybrid(ctx context.Context, prompt string, frontPath, leftPath, rightPath, backPath string) string {
-	apiKey := os.Getenv("GOOGLE_API_KEY")
-	if apiKey == "" {
-		log.Fatal("Error: GOOGLE_API_KEY not set")
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
 	}
+	var frames []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".jpg", ".jpeg", ".png":
+			frames = append(frames, filepath.Join(path, e.Name()))
+		}
+	}
+	sort.Strings(frames)
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("no frame images found in %s", path)
+	}
+	return frames, nil
+}
 
-	modelName := os.Getenv("GEMINI_MODEL")
-	if modelName == "" {
-		modelName = "gemini-2.5-flash-lite"
+// extractVideoFrames shells out to ffmpeg to sample frames from a
+// video at the given rate - the same "spawn an external process"
+// pattern pkg/poseextractor uses for the MediaPipe server. Frames
+// land in <outputDir>/video_frames so they're easy to inspect
+// alongside the rest of a run's output.
+func extractVideoFrames(video string, fps float64, outputDir string) ([]string, error) {
+	frameDir := filepath.Join(outputDir, "video_frames")
+	if err := os.MkdirAll(frameDir, 0755); err != nil {
+		return nil, err
 	}
 
-	client, err := genai.NewClient(ctx, option.WithAPIKey(apiKey))
-	if err != nil {
-		log.Fatalf("Error creating Gemini client: %v", err)
+	cmd := exec.Command("ffmpeg", "-i", video, "-vf", fmt.Sprintf("fps=%g", fps), filepath.Join(frameDir, "frame_%04d.jpg"))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("ffmpeg frame extraction failed: %w", err)
 	}
-	defer client.Close()
 
-	model := client.GenerativeModel(modelName)
-	model.SetTemperature(0.0)
-	model.SetTopK(1)
-	model.SetTopP(0.0)
-	model.ResponseMIMEType = "application/json"
+	return resolveFrames(frameDir)
+}
 
-	// Build parts with prompt + images + annotated images
-	var parts []genai.Part
-	parts = append(parts, genai.Text(prompt))
+// extractPoseMetrics measures the clinical angles MediaPipe can
+// derive directly from the four view images. It's the single-frame
+// case of extractPoseMetricsFrames, kept around under its original
+// signature since callers like serve_hybrid.go only ever hand it one
+// image per view and don't care about the timeseries.
+func extractPoseMetrics(front, left, right, back string, height float64, outputDir string) (*PoseMetrics, error) {
+	metrics, _, err := extractPoseMetricsFrames(outputDir, height, []string{front}, []string{left}, []string{right}, []string{back}, aggregate.DefaultMinVisibility)
+	return metrics, err
+}
 
-	// Add images
-	addImage := func(label, path string) {
-		data, err := os.ReadFile(path)
-		if err != nil {
-			return
-		}
-		mimeType := "jpeg"
-		if strings.HasSuffix(strings.ToLower(path), ".png") {
-			mimeType = "png"
-		}
-		parts = append(parts, genai.Text(fmt.Sprintf("\n[%s]", label)))
-		parts = append(parts, genai.ImageData(mimeType, data))
+// extractPoseMetricsFrames is the multi-frame counterpart:
+// front/left/right/backFrames are zipped by index into synchronized
+// frame sets (see pose_extractor.proto), each yielding one sample per
+// metric, and the series is reduced with pkg/aggregate - dropping
+// frames below minVisibility and summarizing what's left as a
+// confidence-weighted mean - into the PoseMetrics that feeds the LLM
+// prompt. The actual extraction runs in a long-lived
+// pose_extractor_server.py process talked to over gRPC (see
+// pkg/poseextractor): the first call in this process spawns and
+// health-checks it, every later call reuses the same connection
+// instead of paying MediaPipe's model load time again. It also
+// returns the raw per-frame series, JSON-encoded, for
+// metrics_timeseries.json and the report's sparklines.
+func extractPoseMetricsFrames(outputDir string, height float64, frontFrames, leftFrames, rightFrames, backFrames []string, minVisibility float64) (*PoseMetrics, []byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	series, err := poseextractor.ExtractMetricsSeries(ctx, frontFrames, leftFrames, rightFrames, backFrames, height, outputDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("pose extraction failed: %w", err)
 	}
 
-	addImage("Front View", frontPath)
-	addImage("Left Side View", leftPath)
-	addImage("Right Side View", rightPath)
-	addImage("Back View", backPath)
-
-	fmt.Printf("Sending to Gemini (%s) with images + measured data...\n", modelName)
-	resp, err := model.GenerateContent(ctx, parts...)
+	timeseriesJSON, err := json.MarshalIndent(series, "", "  ")
 	if err != nil {
-		log.Fatalf("Error generating content: %v", err)
+		return nil, nil, fmt.Errorf("marshaling metrics timeseries: %w", err)
 	}
 
-	var result strings.Builder
-	for _, cand := range resp.Candidates {
-		if cand.Content != nil {
-			for _, part := range cand.Content.Parts {
-				if txt, ok := part.(genai.Text); ok {
-					result.WriteString(string(txt))
-				}
-			}
+	summarize := func(name string) *Metric {
+		samples, ok := series[name]
+		if !ok {
+			return nil
+		}
+		summary := aggregate.Reduce(samples, minVisibility)
+		if summary.FrameCount == 0 {
+			log.Printf("Warning: %s omitted - all %d sample(s) fell below min-visibility %.2f", name, len(samples), minVisibility)
+			return nil
 		}
+		return &Metric{Value: summary.WeightedMean, Unit: summary.Unit, Confidence: summary.Confidence}
 	}
-	return result.String()
-}
 
-func runOpenAIHybrid(ctx context.Context, prompt string, frontPath, leftPath, rightPath, backPath string) string {
-	apiKey := os.Getenv("OPENAI_API_KEY")
-	if apiKey == "" {
-		log.Fatal("Error: OPENAI_API_KEY not set")
+	metrics := &PoseMetrics{
+		CraniovertebralAngle: summarize("craniovertebral_angle"),
+		ForwardHeadPosture:   summarize("forward_head_posture"),
+		ShoulderHeightDelta:  summarize("shoulder_height_delta"),
+		ThoracicKyphosis:     summarize("thoracic_kyphosis"),
+		KneeValgusVarus:      summarize("knee_valgus_varus"),
+		FootProgressionAngle: summarize("foot_progression_angle"),
 	}
+	return metrics, timeseriesJSON, nil
+}
 
-	modelName := os.Getenv("OPENAI_MODEL")
-	if modelName == "" {
-		modelName = "gpt-4o-mini"
+// getLLMInterpretation asks a vision-capable LLM to turn the measured
+// metrics, the deterministic rule-engine severities (pkg/scoring), and
+// the four view images into a clinical interpretation. Feeding the
+// model severities instead of just raw numbers keeps it interpreting
+// ground truth rather than inventing its own severity judgment.
+// provider selects the pkg/llm backend by name ("gemini", "openai",
+// "openai-compatible", ...); an unknown or unconfigured provider is a
+// fatal error here since this is a one-shot CLI with no fallback path.
+func getLLMInterpretation(metrics *PoseMetrics, severities []scoring.RegionSeverity, provider string, frontPath, leftPath, rightPath, backPath string) string {
+	// Load prompt template
+	promptTemplate, err := os.ReadFile("prompt_hybrid.txt")
+	if err != nil {
+		log.Fatalf("Error reading prompt: %v", err)
 	}
 
-	client := openai.NewClient(apiKey)
+	// Inject metrics and deterministic severities into prompt
+	metricsJSON, _ := json.MarshalIndent(metrics, "", "  ")
+	prompt := strings.Replace(string(promptTemplate), "{METRICS_JSON}", string(metricsJSON), 1)
+	severitiesJSON, _ := json.MarshalIndent(severities, "", "  ")
+	prompt = strings.Replace(prompt, "{SEVERITIES_JSON}", string(severitiesJSON), 1)
 
-	// Encode images to base64
-	encodeImage := func(path string) string {
-		data, err := os.ReadFile(path)
-		if err != nil {
-			return ""
-		}
-		return base64.StdEncoding.EncodeToString(data)
-	}
-
-	// Build message with images
-	messages := []openai.ChatCompletionMessage{
-		{
-			Role: openai.ChatMessageRoleUser,
-			MultiContent: []openai.ChatMessagePart{
-				{Type: openai.ChatMessagePartTypeText, Text: prompt},
-				{Type: openai.ChatMessagePartTypeImageURL, ImageURL: &openai.ChatMessageImageURL{
-					URL: "data:image/jpeg;base64," + encodeImage(frontPath),
-				}},
-				{Type: openai.ChatMessagePartTypeImageURL, ImageURL: &openai.ChatMessageImageURL{
-					URL: "data:image/jpeg;base64," + encodeImage(leftPath),
-				}},
-				{Type: openai.ChatMessagePartTypeImageURL, ImageURL: &openai.ChatMessageImageURL{
-					URL: "data:image/jpeg;base64," + encodeImage(rightPath),
-				}},
-				{Type: openai.ChatMessagePartTypeImageURL, ImageURL: &openai.ChatMessageImageURL{
-					URL: "data:image/jpeg;base64," + encodeImage(backPath),
-				}},
-			},
-		},
+	backend, err := llm.Get(provider)
+	if err != nil {
+		log.Fatalf("Error selecting LLM backend: %v", err)
 	}
 
-	resp, err := client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
-		Model:          modelName,
-		Messages:       messages,
-		Temperature:    0.0,
-		ResponseFormat: &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject},
-	})
+	images := []llm.ImageRef{
+		{Label: "Front View", Path: frontPath},
+		{Label: "Left Side View", Path: leftPath},
+		{Label: "Right Side View", Path: rightPath},
+		{Label: "Back View", Path: backPath},
+	}
 
+	fmt.Printf("Sending to %s with images + measured data...\n", backend.Name())
+	result, err := backend.Interpret(context.Background(), prompt, images)
 	if err != nil {
-		log.Fatalf("Error calling OpenAI: %v", err)
+		log.Fatalf("Error getting LLM interpretation: %v", err)
 	}
-
-	return resp.Choices[0].Message.Content
+	return result
 }
 
 func printMetrics(m *PoseMetrics) {
@@ -307,7 +382,7 @@ func createOutputDir() string {
 	return outputDir
 }
 
-func saveResults(outputDir string, metrics *PoseMetrics, interpretation string, frontPath, leftPath, rightPath, backPath string) {
+func saveResults(outputDir string, metrics *PoseMetrics, interpretation string, frontPath, leftPath, rightPath, backPath string, timeseriesJSON []byte, deterministicScore int, evidence scoring.Evidence) {
 	// Save metrics
 	metricsFile := filepath.Join(outputDir, "metrics.json")
 	metricsJSON, _ := json.MarshalIndent(metrics, "", "  ")
@@ -324,6 +399,26 @@ func saveResults(outputDir string, metrics *PoseMetrics, interpretation string,
 	// Build Regions array from body sections for template
 	buildRegionsAndScore(interpData)
 
+	// The LLM's narrative Regions stay as-is for display, but the
+	// numeric score is overridden with the deterministic, reproducible
+	// one from pkg/scoring so two runs over the same metrics always
+	// report the same PostureScore regardless of what the model wrote.
+	interpData["PostureScore"] = deterministicScore
+	interpData["DeterministicEvidence"] = evidence.Regions
+
+	evidenceFile := filepath.Join(outputDir, "evidence.json")
+	evidenceJSON, _ := json.MarshalIndent(evidence, "", "  ")
+	os.WriteFile(evidenceFile, evidenceJSON, 0644)
+
+	// Make the per-frame series available to the report template so
+	// it can draw a sparkline per metric (via the "sparkline" func).
+	if len(timeseriesJSON) > 0 {
+		var series map[string][]aggregate.Sample
+		if err := json.Unmarshal(timeseriesJSON, &series); err == nil {
+			interpData["MetricsTimeseries"] = series
+		}
+	}
+
 	// Copy images to output directory
 	copyImage(frontPath, filepath.Join(outputDir, "front"+filepath.Ext(frontPath)))
 	copyImage(leftPath, filepath.Join(outputDir, "left"+filepath.Ext(leftPath)))
@@ -454,6 +549,7 @@ func generateHTMLReport(outputDir string, data map[string]interface{}) {
 		"toFloat": func(i int) float64 {
 			return float64(i)
 		},
+		"sparkline": aggregate.Sparkline,
 	}
 
 	tmpl, err := template.New(filepath.Base(templatePath)).Funcs(tmplFuncs).ParseFiles(templatePath)
@@ -0,0 +1,95 @@
+// Package aggregate reduces a per-frame series of pose measurements
+// into a single robust summary. Single-frame measurements are noisy
+// (a blink of MediaPipe mistracking a landmark shifts an angle by a
+// few degrees); sampling several frames and reducing them with
+// visibility-weighted statistics instead of taking one frame at face
+// value is the standard way to get a stable clinical number.
+package aggregate
+
+import "sort"
+
+// DefaultMinVisibility is the MediaPipe landmark-visibility threshold
+// below which a frame is dropped before aggregation.
+const DefaultMinVisibility = 0.5
+
+// Sample is one frame's measurement of a single metric.
+type Sample struct {
+	Value      float64 `json:"value"`
+	Unit       string  `json:"unit"`
+	Confidence float64 `json:"confidence"`
+	Visibility float64 `json:"visibility"`
+}
+
+// Summary is the robust reduction of a Sample series into one value.
+type Summary struct {
+	Median        float64 `json:"median"`
+	IQR           float64 `json:"iqr"`
+	WeightedMean  float64 `json:"weighted_mean"`
+	Unit          string  `json:"unit"`
+	Confidence    float64 `json:"confidence"`
+	FrameCount    int     `json:"frame_count"`
+	RejectedCount int     `json:"rejected_count"`
+}
+
+// Reduce drops frames whose landmark visibility falls below
+// minVisibility, then summarizes what's left as a median plus IQR
+// (robust to outlier frames) and a visibility-weighted mean (weight =
+// each frame's MediaPipe landmark visibility). Summary.FrameCount is 0
+// if every frame was rejected.
+func Reduce(samples []Sample, minVisibility float64) Summary {
+	accepted := make([]Sample, 0, len(samples))
+	rejected := 0
+	for _, s := range samples {
+		if s.Visibility < minVisibility {
+			rejected++
+			continue
+		}
+		accepted = append(accepted, s)
+	}
+	if len(accepted) == 0 {
+		return Summary{RejectedCount: rejected}
+	}
+
+	values := make([]float64, len(accepted))
+	for i, s := range accepted {
+		values[i] = s.Value
+	}
+	sort.Float64s(values)
+
+	var weightedSum, weightSum, confidenceSum float64
+	for _, s := range accepted {
+		weightedSum += s.Value * s.Visibility
+		weightSum += s.Visibility
+		confidenceSum += s.Confidence
+	}
+	weightedMean := percentile(values, 0.5)
+	if weightSum > 0 {
+		weightedMean = weightedSum / weightSum
+	}
+
+	return Summary{
+		Median:        percentile(values, 0.5),
+		IQR:           percentile(values, 0.75) - percentile(values, 0.25),
+		WeightedMean:  weightedMean,
+		Unit:          accepted[0].Unit,
+		Confidence:    confidenceSum / float64(len(accepted)),
+		FrameCount:    len(accepted),
+		RejectedCount: rejected,
+	}
+}
+
+// percentile linearly interpolates the p-th percentile (0-1) of an
+// already-sorted slice.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	idx := p * float64(len(sorted)-1)
+	lo := int(idx)
+	hi := lo + 1
+	if hi >= len(sorted) {
+		return sorted[lo]
+	}
+	frac := idx - float64(lo)
+	return sorted[lo]*(1-frac) + sorted[hi]*frac
+}
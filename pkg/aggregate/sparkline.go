@@ -0,0 +1,45 @@
+package aggregate
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// Sparkline renders a minimal inline SVG line chart of a sample
+// series' values, for embedding a metric's per-frame trend next to
+// its aggregated Summary in the HTML report. Returns "" for a series
+// too short to draw a line through.
+func Sparkline(samples []Sample) template.HTML {
+	const width, height = 120.0, 24.0
+	if len(samples) < 2 {
+		return ""
+	}
+
+	min, max := samples[0].Value, samples[0].Value
+	for _, s := range samples {
+		if s.Value < min {
+			min = s.Value
+		}
+		if s.Value > max {
+			max = s.Value
+		}
+	}
+	span := max - min
+	if span == 0 {
+		span = 1
+	}
+
+	points := make([]string, len(samples))
+	for i, s := range samples {
+		x := float64(i) / float64(len(samples)-1) * width
+		y := height - ((s.Value-min)/span)*height
+		points[i] = fmt.Sprintf("%.1f,%.1f", x, y)
+	}
+
+	svg := fmt.Sprintf(
+		`<svg width="%g" height="%g" viewBox="0 0 %g %g" class="sparkline"><polyline points="%s" fill="none" stroke="currentColor" stroke-width="1.5" /></svg>`,
+		width, height, width, height, strings.Join(points, " "),
+	)
+	return template.HTML(svg)
+}
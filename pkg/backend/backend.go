@@ -0,0 +1,195 @@
+// Package backend loads pluggable posture-analysis backends: standalone
+// binaries that speak the PostureBackend gRPC service defined in
+// proto/posture.proto. This lets new providers (Anthropic, a local
+// Ollama, HuggingFace inference, ...) ship as separate executables
+// instead of being compiled into the CLI.
+package backend
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/pranjal-lnct/PostureAnalysis/pkg/backend/proto"
+)
+
+// Image is one view image handed to a backend's Analyze call.
+type Image struct {
+	Label    string
+	MimeType string
+	Data     []byte
+}
+
+// PostureBackend is the interface every provider (in-process or spawned)
+// is expected to satisfy.
+type PostureBackend interface {
+	// Analyze sends the prompt, images and the expected JSON schema to
+	// the backend and returns the raw JSON result text, plus any
+	// per-field confidences the backend reported independently of the
+	// JSON body (keyed by dotted path, e.g. "spine.thoracic_kyphosis").
+	Analyze(ctx context.Context, promptText string, images []Image, schemaJSON string) (result string, confidences map[string]float32, err error)
+
+	// Close releases any resources (e.g. terminates a spawned process).
+	Close() error
+}
+
+const envBackendPath = "POSTURE_BACKEND_PATH"
+
+// Dir resolves the directory backend binaries are looked up in: the
+// --backend-path flag (passed in explicitly since this package has no
+// flag of its own), then POSTURE_BACKEND_PATH, then "./backends".
+func Dir(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if v := os.Getenv(envBackendPath); v != "" {
+		return v
+	}
+	return "backends"
+}
+
+// grpcBackend is a PostureBackend backed by a subprocess dialed over a
+// Unix socket.
+type grpcBackend struct {
+	cmd    *exec.Cmd
+	conn   *grpc.ClientConn
+	client pb.PostureBackendClient
+	sock   string
+}
+
+// Load spawns the backend binary named `name` from `dir` (e.g.
+// "gemini" -> "<dir>/gemini"), waits for it to report healthy on a
+// Unix socket, and returns a ready-to-use PostureBackend. Callers must
+// Close() the result to terminate the subprocess.
+func Load(ctx context.Context, dir, name string) (PostureBackend, error) {
+	binPath := filepath.Join(dir, name)
+	if _, err := os.Stat(binPath); err != nil {
+		return nil, fmt.Errorf("backend %q not found in %s: %w", name, dir, err)
+	}
+
+	sockPath := filepath.Join(os.TempDir(), fmt.Sprintf("posture-backend-%s-%d.sock", name, os.Getpid()))
+	os.Remove(sockPath)
+
+	cmd := exec.CommandContext(ctx, binPath, "--socket", sockPath)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting backend %q: %w", name, err)
+	}
+
+	conn, err := dialUnix(ctx, sockPath)
+	if err != nil {
+		cmd.Process.Kill()
+		return nil, fmt.Errorf("dialing backend %q: %w", name, err)
+	}
+
+	client := pb.NewPostureBackendClient(conn)
+	if err := waitHealthy(ctx, client); err != nil {
+		conn.Close()
+		cmd.Process.Kill()
+		return nil, fmt.Errorf("backend %q never became healthy: %w", name, err)
+	}
+
+	return &grpcBackend{cmd: cmd, conn: conn, client: client, sock: sockPath}, nil
+}
+
+func dialUnix(ctx context.Context, sockPath string) (*grpc.ClientConn, error) {
+	dialer := func(ctx context.Context, addr string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", addr)
+	}
+
+	// The socket file may not exist the instant the process forks, so
+	// grpc.DialContext with WithBlock polls via the dialer until ctx
+	// expires or the server starts listening.
+	dialCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	return grpc.DialContext(dialCtx, sockPath,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(dialer),
+		grpc.WithBlock(),
+	)
+}
+
+func waitHealthy(ctx context.Context, client pb.PostureBackendClient) error {
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		hctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		resp, err := client.Health(hctx, &pb.HealthRequest{})
+		cancel()
+		if err == nil && resp.Ready {
+			return nil
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+	return fmt.Errorf("timed out waiting for health check")
+}
+
+func (b *grpcBackend) Analyze(ctx context.Context, promptText string, images []Image, schemaJSON string) (string, map[string]float32, error) {
+	req := &pb.AnalyzeRequest{
+		PromptText: promptText,
+		SchemaJson: schemaJSON,
+	}
+	for _, img := range images {
+		req.Images = append(req.Images, &pb.ImagePart{
+			Label:    img.Label,
+			MimeType: img.MimeType,
+			Data:     img.Data,
+		})
+	}
+
+	resp, err := b.client.Analyze(ctx, req)
+	if err != nil {
+		return "", nil, fmt.Errorf("backend Analyze: %w", err)
+	}
+	return resp.ResultJson, resp.Confidences, nil
+}
+
+// Connect dials a backend that is already running at addr, rather
+// than spawning one. This is what AI_PROVIDER=grpc uses: point
+// GRPC_BACKEND_ADDR at a long-lived external vision model server
+// (LLaVA, Qwen-VL, a remote Ollama, ...) without this process owning
+// its lifecycle.
+func Connect(ctx context.Context, addr string) (PostureBackend, error) {
+	dialCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(dialCtx, addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("dialing backend at %s: %w", addr, err)
+	}
+	return &grpcBackend{conn: conn, client: pb.NewPostureBackendClient(conn)}, nil
+}
+
+// Close requests a graceful shutdown of the spawned subprocess,
+// falling back to Kill if it doesn't exit in time.
+func (b *grpcBackend) Close() error {
+	b.conn.Close()
+	if b.sock != "" {
+		os.Remove(b.sock)
+	}
+
+	if b.cmd == nil || b.cmd.Process == nil {
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- b.cmd.Wait() }()
+
+	b.cmd.Process.Signal(os.Interrupt)
+	select {
+	case <-done:
+		return nil
+	case <-time.After(5 * time.Second):
+		return b.cmd.Process.Kill()
+	}
+}
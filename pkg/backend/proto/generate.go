@@ -0,0 +1,9 @@
+package proto
+
+// Regenerate posture.pb.go and posture_grpc.pb.go from posture.proto
+// with protoc and the Go plugins:
+//
+//	go install google.golang.org/protobuf/cmd/protoc-gen-go@latest
+//	go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@latest
+//
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative posture.proto
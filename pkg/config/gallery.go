@@ -0,0 +1,92 @@
+// Package config loads the models.yaml gallery: named provider/model
+// profiles, plus an ordered fallback chain between them. This lets
+// users declare profiles once instead of juggling env vars and flags,
+// and A/B models by swapping --profile.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profile is one named entry in the gallery.
+type Profile struct {
+	Name         string   `yaml:"name"`
+	Provider     string   `yaml:"provider"` // "gemini", "openai", or a backend name
+	Model        string   `yaml:"model"`
+	Temperature  *float64 `yaml:"temperature,omitempty"`
+	TopK         *int     `yaml:"top_k,omitempty"`
+	TimeoutSec   int      `yaml:"timeout_seconds,omitempty"`
+	APIKeyEnv    string   `yaml:"api_key_env,omitempty"`
+	PromptFile   string   `yaml:"prompt_file,omitempty"`   // overrides the default prompt.txt
+	TemplateFile string   `yaml:"template_file,omitempty"` // overrides the default template.html
+	Fallback     []string `yaml:"fallback,omitempty"`      // ordered list of profile names to try next
+}
+
+// Gallery is the parsed models.yaml: every declared profile, keyed by name.
+type Gallery struct {
+	Profiles map[string]Profile
+}
+
+// Load reads and parses a models.yaml gallery file.
+func Load(path string) (*Gallery, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading gallery %s: %w", path, err)
+	}
+
+	var raw struct {
+		Profiles []Profile `yaml:"profiles"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing gallery %s: %w", path, err)
+	}
+
+	g := &Gallery{Profiles: make(map[string]Profile, len(raw.Profiles))}
+	for _, p := range raw.Profiles {
+		if p.Name == "" {
+			return nil, fmt.Errorf("gallery %s: profile missing a name", path)
+		}
+		g.Profiles[p.Name] = p
+	}
+	return g, nil
+}
+
+// Profile looks up a named profile.
+func (g *Gallery) Profile(name string) (Profile, bool) {
+	p, ok := g.Profiles[name]
+	return p, ok
+}
+
+// Chain returns the profile named `name` followed by its fallback
+// chain, resolved transitively and with cycles/duplicates removed.
+func (g *Gallery) Chain(name string) ([]Profile, error) {
+	var chain []Profile
+	seen := map[string]bool{}
+
+	var walk func(n string) error
+	walk = func(n string) error {
+		if seen[n] {
+			return nil
+		}
+		p, ok := g.Profile(n)
+		if !ok {
+			return fmt.Errorf("profile %q not found in gallery", n)
+		}
+		seen[n] = true
+		chain = append(chain, p)
+		for _, next := range p.Fallback {
+			if err := walk(next); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(name); err != nil {
+		return nil, err
+	}
+	return chain, nil
+}
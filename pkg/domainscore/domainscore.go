@@ -0,0 +1,152 @@
+// Package domainscore turns the flat per-metric severities the LLM
+// returns (mild/moderate/severe) into a 0-100 score per anatomical
+// region plus a composite index, replacing a flat score that summed
+// severity weights across every metric regardless of region - where a
+// single severe finding in one region could outweigh many mild issues
+// spread across the others.
+package domainscore
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Finding is one metric that contributed to a domain's score.
+type Finding struct {
+	Metric   string `json:"metric"`
+	Severity string `json:"severity"`
+	Weight   int    `json:"weight"`
+}
+
+// Domain is one region's computed score plus the findings behind it,
+// most severe first.
+type Domain struct {
+	Score    int       `json:"score"`
+	Findings []Finding `json:"findings"`
+}
+
+var severityWeight = map[string]int{
+	"severe":   15,
+	"moderate": 10,
+	"mild":     5,
+}
+
+// DefaultWeight is the composite weight used for any region missing
+// from the caller's weights map, so unlisted regions count equally
+// with listed ones.
+const DefaultWeight = 1.0
+
+// Score computes a 0-100 score per region and a composite index as
+// the weighted mean of the region scores.
+//
+// regions is the same []map[string]interface{}{"Title", "Data"} shape
+// main.go already builds for its template; Data is expected to be a
+// map of metric name to a map carrying a "severity" string. weights
+// maps a region's Title to its composite weight; a region absent from
+// weights gets DefaultWeight, so a nil or empty map weights every
+// region equally. A region present in weights with an explicit weight
+// of 0 is not reweighted to DefaultWeight - it's excluded from the
+// composite entirely, which is what lets --domain-weights
+// "Region=0" drop a region instead of just equalizing it.
+//
+// A region's score is 100 minus the rounded average severity weight
+// (severe 15, moderate 10, mild 5) across the metrics evaluated in it,
+// rather than the sum, so a region with more measured items isn't
+// unfairly penalized relative to one with few. Regions with no
+// evaluated metrics are left out of both the per-region scores and the
+// composite.
+func Score(regions []map[string]interface{}, weights map[string]float64) (map[string]int, map[string]Domain, int) {
+	domainScores := make(map[string]int)
+	domainDetails := make(map[string]Domain)
+
+	var weightedSum, weightTotal float64
+	for _, region := range regions {
+		title, _ := region["Title"].(string)
+		dataMap, ok := region["Data"].(map[string]interface{})
+		if !ok || title == "" {
+			continue
+		}
+
+		var findings []Finding
+		lost, evaluated := 0, 0
+		for metric, v := range dataMap {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			sev, _ := m["severity"].(string)
+			if sev == "" {
+				continue
+			}
+			evaluated++
+			if w, ok := severityWeight[sev]; ok {
+				lost += w
+				findings = append(findings, Finding{Metric: metric, Severity: sev, Weight: w})
+			}
+		}
+		if evaluated == 0 {
+			continue
+		}
+		sort.Slice(findings, func(i, j int) bool { return findings[i].Weight > findings[j].Weight })
+
+		score := 100 - int(float64(lost)/float64(evaluated)+0.5)
+		if score < 0 {
+			score = 0
+		}
+		if score > 100 {
+			score = 100
+		}
+
+		domainScores[title] = score
+		domainDetails[title] = Domain{Score: score, Findings: findings}
+
+		w, ok := weights[title]
+		if !ok {
+			w = DefaultWeight
+		}
+		weightedSum += float64(score) * w
+		weightTotal += w
+	}
+
+	composite := 100
+	if weightTotal > 0 {
+		composite = int(weightedSum/weightTotal + 0.5)
+	}
+	return domainScores, domainDetails, composite
+}
+
+// WeightsEnv resolves the raw "Region=weight,..." string to parse:
+// flagValue, then DOMAIN_WEIGHTS, then "" (equal weights). Shared by
+// every entry point that exposes a --domain-weights flag so they can't
+// drift on how the flag and env var are prioritized.
+func WeightsEnv(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	return os.Getenv("DOMAIN_WEIGHTS")
+}
+
+// ParseWeights parses WeightsEnv's "Region=weight,..." format into the
+// map Score expects. Malformed or non-numeric entries are skipped
+// rather than failing the whole run, since a bad weight shouldn't
+// block report generation.
+func ParseWeights(s string) map[string]float64 {
+	weights := make(map[string]float64)
+	if s == "" {
+		return weights
+	}
+	for _, pair := range strings.Split(s, ",") {
+		region, weightStr, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(weightStr), 64)
+		if err != nil {
+			continue
+		}
+		weights[strings.TrimSpace(region)] = weight
+	}
+	return weights
+}
@@ -0,0 +1,204 @@
+// Package embeddings turns a finished analysis into a vector so runs
+// can be compared longitudinally instead of read one at a time. Each
+// run appends an entry (timestamp, PostureScore, embedding) to a flat
+// output/index.jsonl, and the `compare` subcommand finds the nearest
+// prior sessions by cosine similarity.
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// Provider embeds a piece of text into a fixed-size vector.
+type Provider interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
+
+// New builds a Provider by name ("gemini" or "openai"), matching the
+// AI_PROVIDER values used for analysis itself.
+func New(name string) (Provider, error) {
+	switch name {
+	case "openai":
+		return &openAIEmbedder{}, nil
+	case "gemini":
+		return &geminiEmbedder{}, nil
+	default:
+		return nil, fmt.Errorf("unknown embeddings provider %q (want openai or gemini)", name)
+	}
+}
+
+type openAIEmbedder struct{}
+
+func (e *openAIEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("OPENAI_API_KEY not set")
+	}
+
+	modelName := os.Getenv("OPENAI_EMBEDDING_MODEL")
+	if modelName == "" {
+		modelName = string(openai.SmallEmbedding3)
+	}
+
+	client := openai.NewClient(apiKey)
+	resp, err := client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
+		Input: []string{text},
+		Model: openai.EmbeddingModel(modelName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("OpenAI embeddings: %w", err)
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("OpenAI embeddings: empty response")
+	}
+	return resp.Data[0].Embedding, nil
+}
+
+type geminiEmbedder struct{}
+
+func (e *geminiEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	apiKey := os.Getenv("GOOGLE_API_KEY")
+	if apiKey == "" {
+		apiKey = os.Getenv("GEMINI_API_KEY")
+	}
+	if apiKey == "" {
+		return nil, fmt.Errorf("GOOGLE_API_KEY not set")
+	}
+
+	modelName := os.Getenv("GEMINI_EMBEDDING_MODEL")
+	if modelName == "" {
+		modelName = "text-embedding-004"
+	}
+
+	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:embedContent?key=%s", modelName, apiKey)
+	body, err := json.Marshal(map[string]interface{}{
+		"model":   "models/" + modelName,
+		"content": map[string]interface{}{"parts": []map[string]string{{"text": text}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Gemini embeddings request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var parsed struct {
+		Embedding struct {
+			Values []float32 `json:"values"`
+		} `json:"embedding"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("parsing Gemini embeddings response: %w", err)
+	}
+	return parsed.Embedding.Values, nil
+}
+
+// Entry is one row of output/index.jsonl: a run's timestamp, its
+// overall PostureScore, and the embedding of its clinical reasoning
+// plus serialized metric values.
+type Entry struct {
+	Timestamp    string    `json:"timestamp"`
+	PostureScore int       `json:"posture_score"`
+	Embedding    []float32 `json:"embedding"`
+}
+
+// AppendToIndex appends one entry as a JSON line to indexPath,
+// creating the file if needed.
+func AppendToIndex(indexPath string, entry Entry) error {
+	f, err := os.OpenFile(indexPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("opening index %s: %w", indexPath, err)
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// LoadIndex reads every entry from indexPath. A missing file is not
+// an error; it just means there is no history yet.
+func LoadIndex(indexPath string) ([]Entry, error) {
+	data, err := os.ReadFile(indexPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading index %s: %w", indexPath, err)
+	}
+
+	var entries []Entry
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for dec.More() {
+		var e Entry
+		if err := dec.Decode(&e); err != nil {
+			return nil, fmt.Errorf("parsing index %s: %w", indexPath, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// CosineSimilarity compares two embeddings of equal length.
+func CosineSimilarity(a, b []float32) float64 {
+	var dot, normA, normB float64
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// Nearest returns the K entries in entries most similar to query by
+// cosine similarity, most similar first.
+func Nearest(entries []Entry, query []float32, k int) []Entry {
+	type scored struct {
+		entry Entry
+		score float64
+	}
+	scoredEntries := make([]scored, len(entries))
+	for i, e := range entries {
+		scoredEntries[i] = scored{entry: e, score: CosineSimilarity(e.Embedding, query)}
+	}
+	sort.Slice(scoredEntries, func(i, j int) bool {
+		return scoredEntries[i].score > scoredEntries[j].score
+	})
+
+	if k > len(scoredEntries) {
+		k = len(scoredEntries)
+	}
+	out := make([]Entry, k)
+	for i := 0; i < k; i++ {
+		out[i] = scoredEntries[i].entry
+	}
+	return out
+}
@@ -0,0 +1,198 @@
+// Package exercises loads the corrective-exercise catalog from a
+// directory of TOML rule files (one per exercise, each pairable with a
+// Markdown file of the same name for the full write-up) and matches
+// them against a finished analysis, replacing the severity ladder that
+// used to be hard-coded in generateExerciseRecommendations.
+package exercises
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	blackfriday "github.com/russross/blackfriday/v2"
+)
+
+// Trigger is one condition a rule checks against the analysis JSON,
+// e.g. {Path: "spine.thoracic_kyphosis.severity", Operator:
+// "severity_at_least", Value: "moderate"}.
+type Trigger struct {
+	Path     string `toml:"path"`
+	Operator string `toml:"operator"`
+	Value    string `toml:"value"`
+}
+
+// Rule is a set of Triggers combined with AND ("all") or OR ("any").
+type Rule struct {
+	Match    string    `toml:"match"`
+	Triggers []Trigger `toml:"triggers"`
+}
+
+// Entry is one exercise's TOML metadata plus its optional Markdown
+// body, loaded from <dir>/<slug>.toml and <dir>/<slug>.md.
+type Entry struct {
+	Name        string `toml:"name"`
+	Description string `toml:"description"`
+	Frequency   string `toml:"frequency"`
+	Purpose     string `toml:"purpose"`
+	Priority    int    `toml:"priority"`
+	Rule        Rule   `toml:"rule"`
+
+	detailHTML template.HTML
+}
+
+// Exercise is what callers actually want to render: the same shape
+// main.go's template already expects, plus the rendered Markdown body.
+type Exercise struct {
+	Name        string
+	Description string
+	Frequency   string
+	Purpose     string
+	Priority    int
+	DetailHTML  template.HTML
+}
+
+// Catalog is the full set of exercises loaded from EXERCISES_DIR.
+type Catalog struct {
+	entries []Entry
+}
+
+const envExercisesDir = "EXERCISES_DIR"
+
+// Dir resolves the catalog directory: the --exercises-dir flag
+// (passed in explicitly, same convention as backend.Dir), then
+// EXERCISES_DIR, then "exercises".
+func Dir(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if v := os.Getenv(envExercisesDir); v != "" {
+		return v
+	}
+	return "exercises"
+}
+
+// Load reads every *.toml file in dir into a Catalog, rendering a
+// sibling <slug>.md as the exercise's detail HTML when present. A
+// missing or empty directory yields an empty Catalog, not an error,
+// since the exercise recommendations are an optional enhancement to
+// the report.
+func Load(dir string) (*Catalog, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.toml"))
+	if err != nil {
+		return nil, fmt.Errorf("scanning %s: %w", dir, err)
+	}
+	sort.Strings(matches)
+
+	catalog := &Catalog{}
+	for _, path := range matches {
+		var entry Entry
+		if _, err := toml.DecodeFile(path, &entry); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+
+		mdPath := strings.TrimSuffix(path, ".toml") + ".md"
+		if body, err := os.ReadFile(mdPath); err == nil {
+			entry.detailHTML = template.HTML(blackfriday.Run(body))
+		}
+
+		catalog.entries = append(catalog.entries, entry)
+	}
+	return catalog, nil
+}
+
+// Recommend evaluates every entry's rule against analysisData and
+// returns the exercises whose rule matched, deduplicated by name and
+// ordered highest-priority first (ties keep catalog order). Dedup
+// matters because different rules can legitimately recommend the same
+// exercise for different regions; a reader shouldn't see "Chin Tucks"
+// twice just because both the head/neck and shoulder triggers fired.
+func (c *Catalog) Recommend(analysisData map[string]interface{}) []Exercise {
+	var out []Exercise
+	seen := make(map[string]bool)
+	for _, entry := range c.entries {
+		if seen[entry.Name] || !matchRule(entry.Rule, analysisData) {
+			continue
+		}
+		seen[entry.Name] = true
+		out = append(out, Exercise{
+			Name:        entry.Name,
+			Description: entry.Description,
+			Frequency:   entry.Frequency,
+			Purpose:     entry.Purpose,
+			Priority:    entry.Priority,
+			DetailHTML:  entry.detailHTML,
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Priority > out[j].Priority
+	})
+	return out
+}
+
+var severityRank = map[string]int{
+	"":         0,
+	"unknown":  0,
+	"normal":   0,
+	"none":     0,
+	"mild":     1,
+	"moderate": 2,
+	"severe":   3,
+}
+
+func matchRule(r Rule, analysisData map[string]interface{}) bool {
+	if len(r.Triggers) == 0 {
+		return false
+	}
+
+	requireAll := r.Match != "any"
+	for _, t := range r.Triggers {
+		hit := evalTrigger(t, analysisData)
+		if requireAll && !hit {
+			return false
+		}
+		if !requireAll && hit {
+			return true
+		}
+	}
+	return requireAll
+}
+
+func evalTrigger(t Trigger, analysisData map[string]interface{}) bool {
+	value, ok := lookupPath(analysisData, t.Path)
+	if !ok {
+		return false
+	}
+	actual, _ := value.(string)
+
+	switch t.Operator {
+	case "severity_at_least":
+		return severityRank[actual] >= severityRank[t.Value]
+	case "severity_equals":
+		return actual == t.Value
+	default:
+		return false
+	}
+}
+
+// lookupPath walks a dotted path (e.g. "spine.thoracic_kyphosis.severity")
+// through nested map[string]interface{} values.
+func lookupPath(data map[string]interface{}, path string) (interface{}, bool) {
+	parts := strings.Split(path, ".")
+	var current interface{} = data
+	for _, part := range parts {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = m[part]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
@@ -0,0 +1,89 @@
+// Package gemini registers a Gemini-backed llm.Backend.
+package gemini
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/option"
+
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/llm"
+)
+
+func init() {
+	llm.Register("gemini", New)
+}
+
+// New builds a Gemini llm.Backend. Configuration (API key, model) is
+// read from the environment on each Interpret call, not here, so a
+// later env change takes effect without rebuilding the backend.
+func New() (llm.Backend, error) {
+	return &backend{}, nil
+}
+
+type backend struct{}
+
+func (b *backend) Name() string { return "gemini" }
+
+func (b *backend) Interpret(ctx context.Context, prompt string, images []llm.ImageRef) (string, error) {
+	apiKey := os.Getenv("GOOGLE_API_KEY")
+	if apiKey == "" {
+		apiKey = os.Getenv("GEMINI_API_KEY")
+	}
+	if apiKey == "" {
+		return "", fmt.Errorf("GOOGLE_API_KEY not set")
+	}
+
+	modelName := os.Getenv("GEMINI_MODEL")
+	if modelName == "" {
+		modelName = "gemini-2.5-flash-lite"
+	}
+
+	client, err := genai.NewClient(ctx, option.WithAPIKey(apiKey))
+	if err != nil {
+		return "", fmt.Errorf("creating Gemini client: %w", err)
+	}
+	defer client.Close()
+
+	model := client.GenerativeModel(modelName)
+	model.SetTemperature(0.0)
+	model.SetTopK(1)
+	model.SetTopP(0.0)
+	model.ResponseMIMEType = "application/json"
+
+	var parts []genai.Part
+	parts = append(parts, genai.Text(prompt))
+	for _, img := range images {
+		data, err := os.ReadFile(img.Path)
+		if err != nil {
+			continue
+		}
+		mimeType := "jpeg"
+		if strings.HasSuffix(strings.ToLower(img.Path), ".png") {
+			mimeType = "png"
+		}
+		parts = append(parts, genai.Text(fmt.Sprintf("\n[%s]", img.Label)))
+		parts = append(parts, genai.ImageData(mimeType, data))
+	}
+
+	resp, err := model.GenerateContent(ctx, parts...)
+	if err != nil {
+		return "", fmt.Errorf("Gemini GenerateContent: %w", err)
+	}
+
+	var result strings.Builder
+	for _, cand := range resp.Candidates {
+		if cand.Content == nil {
+			continue
+		}
+		for _, part := range cand.Content.Parts {
+			if txt, ok := part.(genai.Text); ok {
+				result.WriteString(string(txt))
+			}
+		}
+	}
+	return result.String(), nil
+}
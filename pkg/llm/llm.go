@@ -0,0 +1,74 @@
+// Package llm defines the common interface every vision-LLM
+// interpretation backend implements, plus a name -> factory registry
+// so main_hybrid.go's getLLMInterpretation can pick one at runtime
+// instead of hard-coding an if/else per provider. Concrete backends
+// (pkg/llm/gemini, pkg/llm/openai) register themselves from an init()
+// function; callers blank-import whichever ones they want available.
+package llm
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// ImageRef is one view image handed to a Backend's Interpret call.
+type ImageRef struct {
+	Label string
+	Path  string
+}
+
+// Backend is a vision-capable LLM that can turn a prompt plus a set
+// of view images into the clinical interpretation JSON text.
+type Backend interface {
+	// Interpret returns the raw JSON text the model produced, or an
+	// error if the request failed - never log.Fatal, so callers can
+	// fall back to another backend instead of the process exiting.
+	Interpret(ctx context.Context, prompt string, images []ImageRef) (string, error)
+	// Name is the registry key this backend was constructed under.
+	Name() string
+}
+
+// Factory builds a Backend. Factories read their own configuration
+// (API keys, model names, base URLs) from the environment when
+// called, the same convention the rest of this repo uses.
+type Factory func() (Backend, error)
+
+var registry = map[string]Factory{}
+
+// Register adds a backend factory under name. Called from the
+// registering package's init(), e.g. pkg/llm/gemini.
+func Register(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// Get builds the backend registered under name.
+func Get(name string) (Backend, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown LLM backend %q (registered: %v)", name, registeredNames())
+	}
+	return factory()
+}
+
+// Default builds the backend named by the LLM_BACKEND env var,
+// falling back to AI_PROVIDER, falling back to "gemini" - the same
+// precedence main.go's AI_PROVIDER handling uses elsewhere.
+func Default() (Backend, error) {
+	name := os.Getenv("LLM_BACKEND")
+	if name == "" {
+		name = os.Getenv("AI_PROVIDER")
+	}
+	if name == "" {
+		name = "gemini"
+	}
+	return Get(name)
+}
+
+func registeredNames() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
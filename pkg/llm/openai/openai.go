@@ -0,0 +1,106 @@
+// Package openai registers OpenAI-backed llm.Backends: the hosted
+// OpenAI API, and an "openai-compatible" variant for local/self-hosted
+// servers that speak the same API (e.g. vLLM, LM Studio) driven by
+// OPENAI_BASE_URL.
+package openai
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	openaiapi "github.com/sashabaranov/go-openai"
+
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/llm"
+)
+
+func init() {
+	llm.Register("openai", New)
+	llm.Register("openai-compatible", NewCompatible)
+}
+
+// New builds the hosted-OpenAI backend, configured via OPENAI_API_KEY
+// and OPENAI_MODEL.
+func New() (llm.Backend, error) {
+	return &backend{name: "openai", defaultModel: "gpt-4o", requireBaseURL: false}, nil
+}
+
+// NewCompatible builds a backend for an OpenAI-compatible local
+// endpoint, configured via OPENAI_BASE_URL (required) and OPENAI_MODEL.
+// OPENAI_API_KEY is optional here since most local servers ignore auth.
+func NewCompatible() (llm.Backend, error) {
+	return &backend{name: "openai-compatible", defaultModel: "gpt-4o", requireBaseURL: true}, nil
+}
+
+type backend struct {
+	name           string
+	defaultModel   string
+	requireBaseURL bool
+}
+
+func (b *backend) Name() string { return b.name }
+
+func (b *backend) Interpret(ctx context.Context, prompt string, images []llm.ImageRef) (string, error) {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	baseURL := os.Getenv("OPENAI_BASE_URL")
+
+	if b.requireBaseURL && baseURL == "" {
+		return "", fmt.Errorf("OPENAI_BASE_URL not set")
+	}
+	if apiKey == "" {
+		if !b.requireBaseURL {
+			return "", fmt.Errorf("OPENAI_API_KEY not set")
+		}
+		// Local OpenAI-compatible servers typically don't check the
+		// key, but the client library requires a non-empty string.
+		apiKey = "local"
+	}
+
+	config := openaiapi.DefaultConfig(apiKey)
+	if baseURL != "" {
+		config.BaseURL = baseURL
+	}
+	client := openaiapi.NewClientWithConfig(config)
+
+	model := os.Getenv("OPENAI_MODEL")
+	if model == "" {
+		model = b.defaultModel
+	}
+
+	parts := []openaiapi.ChatMessagePart{
+		{Type: openaiapi.ChatMessagePartTypeText, Text: prompt},
+	}
+	for _, img := range images {
+		data, err := os.ReadFile(img.Path)
+		if err != nil {
+			continue
+		}
+		encoded := base64.StdEncoding.EncodeToString(data)
+		parts = append(parts, openaiapi.ChatMessagePart{
+			Type: openaiapi.ChatMessagePartTypeText,
+			Text: fmt.Sprintf("[%s]", img.Label),
+		})
+		parts = append(parts, openaiapi.ChatMessagePart{
+			Type: openaiapi.ChatMessagePartTypeImageURL,
+			ImageURL: &openaiapi.ChatMessageImageURL{
+				URL: fmt.Sprintf("data:image/jpeg;base64,%s", encoded),
+			},
+		})
+	}
+
+	resp, err := client.CreateChatCompletion(ctx, openaiapi.ChatCompletionRequest{
+		Model: model,
+		Messages: []openaiapi.ChatCompletionMessage{
+			{Role: openaiapi.ChatMessageRoleUser, MultiContent: parts},
+		},
+		Temperature: 0,
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s CreateChatCompletion: %w", b.name, err)
+	}
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("%s returned no choices", b.name)
+	}
+	return resp.Choices[0].Message.Content, nil
+}
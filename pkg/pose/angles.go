@@ -0,0 +1,72 @@
+package pose
+
+import "math"
+
+// Angles holds the subset of clinically relevant geometric measures
+// that can be derived from a single view's keypoints and compared
+// against what the LLM claims in its narrative.
+type Angles struct {
+	// CraniovertebralAngle is the angle, in degrees, between the
+	// horizontal and the line from the shoulder (tragus approximated
+	// by ear) to the ear landmark. Below ~50 degrees is associated
+	// with forward head posture.
+	CraniovertebralAngle float64
+	// ShoulderHeightDelta is the normalized vertical offset between
+	// the left and right shoulder landmarks; larger magnitude
+	// indicates shoulder asymmetry.
+	ShoulderHeightDelta float64
+	// PelvicObliquity is the normalized vertical offset between the
+	// left and right hip landmarks.
+	PelvicObliquity float64
+	// KneeValgus is the normalized horizontal deviation of each knee
+	// from the line between hip and ankle on the same side, averaged
+	// across both legs; positive values indicate inward collapse.
+	KneeValgus float64
+}
+
+// ComputeAngles derives Angles from a Result's landmarks. Any metric
+// whose required landmarks are missing is left at zero.
+func ComputeAngles(r *Result) Angles {
+	var a Angles
+	if ear, ok := r.Landmarks["right_ear"]; ok {
+		if shoulder, ok := r.Landmarks["right_shoulder"]; ok {
+			a.CraniovertebralAngle = angleFromHorizontal(shoulder, ear)
+		}
+	}
+	if ls, ok := r.Landmarks["left_shoulder"]; ok {
+		if rs, ok := r.Landmarks["right_shoulder"]; ok {
+			a.ShoulderHeightDelta = ls[1] - rs[1]
+		}
+	}
+	if lh, ok := r.Landmarks["left_hip"]; ok {
+		if rh, ok := r.Landmarks["right_hip"]; ok {
+			a.PelvicObliquity = lh[1] - rh[1]
+		}
+	}
+	a.KneeValgus = (kneeDeviation(r, "left") + kneeDeviation(r, "right")) / 2
+	return a
+}
+
+func angleFromHorizontal(from, to Point) float64 {
+	dx := to[0] - from[0]
+	dy := to[1] - from[1]
+	return math.Abs(math.Atan2(dy, dx) * 180 / math.Pi)
+}
+
+// kneeDeviation measures how far the knee sits off the hip-ankle line,
+// as a fraction of hip-to-ankle horizontal span. Returns 0 if any
+// landmark on that side is missing.
+func kneeDeviation(r *Result, side string) float64 {
+	hip, okHip := r.Landmarks[side+"_hip"]
+	knee, okKnee := r.Landmarks[side+"_knee"]
+	ankle, okAnkle := r.Landmarks[side+"_ankle"]
+	if !okHip || !okKnee || !okAnkle {
+		return 0
+	}
+	span := ankle[0] - hip[0]
+	if span == 0 {
+		return 0
+	}
+	expectedX := hip[0] + span*(knee[1]-hip[1])/(ankle[1]-hip[1]+1e-9)
+	return (knee[0] - expectedX) / span
+}
@@ -0,0 +1,185 @@
+// Package pose extracts 2D body keypoints from a single view image
+// ahead of the LLM call, so the vision model is given numeric
+// landmarks and joint angles alongside the raw photo instead of having
+// to eyeball pixel positions itself. Keypoints also give main.go a
+// geometric ground truth it can cross-check the model's narrative
+// severities against.
+package pose
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Point is a normalized (0-1) 2D image coordinate.
+type Point [2]float64
+
+// Result is what a Preprocessor returns for one view image: the
+// detected keypoints, keyed by MediaPipe-style landmark name (e.g.
+// "left_shoulder", "right_hip", "nose"), plus an optional body
+// segmentation mask and the source image's pixel dimensions so
+// normalized landmarks can be placed back onto it.
+type Result struct {
+	Landmarks   map[string]Point `json:"landmarks"`
+	MaskPNG     []byte           `json:"-"`
+	ImageWidth  int              `json:"image_width"`
+	ImageHeight int              `json:"image_height"`
+}
+
+// Preprocessor extracts a Result from a single view image.
+type Preprocessor interface {
+	Process(ctx context.Context, imagePath string) (*Result, error)
+}
+
+const envBackendURL = "POSE_BACKEND_URL"
+
+// BackendURL resolves the pose model server address: the
+// --pose-backend-url flag (passed in explicitly, same convention as
+// backend.Dir), then POSE_BACKEND_URL, then empty (preprocessing
+// disabled).
+func BackendURL(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	return os.Getenv(envBackendURL)
+}
+
+// httpPreprocessor calls a local MediaPipe/SAM-style model server over
+// plain HTTP: POST the image bytes to <baseURL>/pose and get back JSON
+// landmarks plus a base64 mask.
+type httpPreprocessor struct {
+	baseURL string
+	client  *http.Client
+}
+
+// New builds a Preprocessor backed by the model server at baseURL.
+func New(baseURL string) Preprocessor {
+	return &httpPreprocessor{baseURL: baseURL, client: &http.Client{}}
+}
+
+func (p *httpPreprocessor) Process(ctx context.Context, imagePath string) (*Result, error) {
+	file, err := os.Open(imagePath)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", imagePath, err)
+	}
+	defer file.Close()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("image", filepath.Base(imagePath))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/pose", &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("pose backend request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("pose backend returned %s", resp.Status)
+	}
+
+	var parsed struct {
+		Landmarks   map[string]Point `json:"landmarks"`
+		MaskPNGB64  string           `json:"mask_png_base64"`
+		ImageWidth  int              `json:"image_width"`
+		ImageHeight int              `json:"image_height"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("parsing pose backend response: %w", err)
+	}
+
+	result := &Result{
+		Landmarks:   parsed.Landmarks,
+		ImageWidth:  parsed.ImageWidth,
+		ImageHeight: parsed.ImageHeight,
+	}
+	if parsed.MaskPNGB64 != "" {
+		if mask, err := base64.StdEncoding.DecodeString(parsed.MaskPNGB64); err == nil {
+			result.MaskPNG = mask
+		}
+	}
+	return result, nil
+}
+
+// DrawOverlay renders the detected landmarks as small dots on top of
+// the source image at imagePath and returns the result PNG-encoded,
+// for saving next to analysis.json so a reviewer can see exactly what
+// the preprocessor keyed its angles off of.
+func DrawOverlay(imagePath string, result *Result) ([]byte, error) {
+	src, err := decodeImage(imagePath)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", imagePath, err)
+	}
+
+	bounds := src.Bounds()
+	dst := image.NewRGBA(bounds)
+	draw.Draw(dst, bounds, src, bounds.Min, draw.Src)
+
+	dot := color.RGBA{R: 255, G: 64, B: 64, A: 255}
+	for _, pt := range result.Landmarks {
+		x := bounds.Min.X + int(pt[0]*float64(bounds.Dx()))
+		y := bounds.Min.Y + int(pt[1]*float64(bounds.Dy()))
+		drawMarker(dst, x, y, dot)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func drawMarker(dst *image.RGBA, cx, cy int, c color.RGBA) {
+	const radius = 4
+	bounds := dst.Bounds()
+	for dy := -radius; dy <= radius; dy++ {
+		for dx := -radius; dx <= radius; dx++ {
+			if dx*dx+dy*dy > radius*radius {
+				continue
+			}
+			x, y := cx+dx, cy+dy
+			if x < bounds.Min.X || x >= bounds.Max.X || y < bounds.Min.Y || y >= bounds.Max.Y {
+				continue
+			}
+			dst.Set(x, y, c)
+		}
+	}
+}
+
+func decodeImage(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	return img, err
+}
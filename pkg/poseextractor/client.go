@@ -0,0 +1,284 @@
+// Package poseextractor talks to a long-lived MediaPipe pose
+// extraction server over gRPC instead of shelling out to
+// pose_extractor.py on every run. The server is spawned once, on
+// first use, and kept alive for the remainder of the process so
+// repeated extractions (multiple runs against the same server, or a
+// future long-running service) don't re-pay MediaPipe's model load
+// time each time.
+package poseextractor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/aggregate"
+	pb "github.com/pranjal-lnct/PostureAnalysis/pkg/poseextractor/proto"
+)
+
+// Metric mirrors main_hybrid.go's Metric shape, kept independent so
+// this package has no dependency on package main.
+type Metric struct {
+	Value      float64
+	Unit       string
+	Confidence float64
+}
+
+// client is a single persistent connection to the spawned server.
+type client struct {
+	cmd  *exec.Cmd
+	conn *grpc.ClientConn
+	rpc  pb.PoseExtractorClient
+	sock string
+}
+
+const envServerPath = "POSE_EXTRACTOR_SERVER"
+
+// serverPath resolves the Python server script to spawn:
+// POSE_EXTRACTOR_SERVER, then "pose_extractor_server.py".
+func serverPath() string {
+	if v := os.Getenv(envServerPath); v != "" {
+		return v
+	}
+	return "pose_extractor_server.py"
+}
+
+var (
+	mu   sync.Mutex
+	inst *client
+)
+
+// ExtractMetrics spawns the pose extraction server on first call (and
+// reuses it on every subsequent call in this process), sends it the
+// four view images, and returns the metrics it measured. outputDir is
+// where the server should write its *_annotated.jpg overlays.
+func ExtractMetrics(ctx context.Context, front, left, right, back string, heightCM float64, outputDir string) (map[string]Metric, error) {
+	c, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	frontBytes, err := os.ReadFile(front)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", front, err)
+	}
+	leftBytes, err := os.ReadFile(left)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", left, err)
+	}
+	rightBytes, err := os.ReadFile(right)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", right, err)
+	}
+	backBytes, err := os.ReadFile(back)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", back, err)
+	}
+
+	resp, err := c.rpc.ExtractMetrics(ctx, &pb.ExtractMetricsRequest{
+		FrontImage: frontBytes,
+		LeftImage:  leftBytes,
+		RightImage: rightBytes,
+		BackImage:  backBytes,
+		HeightCm:   heightCM,
+		OutputDir:  outputDir,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pose extractor ExtractMetrics: %w", err)
+	}
+
+	out := make(map[string]Metric, len(resp.Metrics))
+	for name, m := range resp.Metrics {
+		out[name] = Metric{Value: m.Value, Unit: m.Unit, Confidence: m.Confidence}
+	}
+	return out, nil
+}
+
+// ExtractMetricsSeries is the multi-frame counterpart to
+// ExtractMetrics: each view is a sequence of frame image paths (e.g.
+// sampled from a short per-view video clip) instead of a single
+// image, and the extractor returns one aggregate.Sample per zipped
+// frame set per metric instead of a single already-aggregated value,
+// so the caller can reduce the series itself (see pkg/aggregate).
+func ExtractMetricsSeries(ctx context.Context, frontFrames, leftFrames, rightFrames, backFrames []string, heightCM float64, outputDir string) (map[string][]aggregate.Sample, error) {
+	c, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	front, err := readFrames(frontFrames)
+	if err != nil {
+		return nil, err
+	}
+	left, err := readFrames(leftFrames)
+	if err != nil {
+		return nil, err
+	}
+	right, err := readFrames(rightFrames)
+	if err != nil {
+		return nil, err
+	}
+	back, err := readFrames(backFrames)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.rpc.ExtractMetrics(ctx, &pb.ExtractMetricsRequest{
+		FrontFrames: front,
+		LeftFrames:  left,
+		RightFrames: right,
+		BackFrames:  back,
+		HeightCm:    heightCM,
+		OutputDir:   outputDir,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pose extractor ExtractMetrics: %w", err)
+	}
+
+	out := make(map[string][]aggregate.Sample, len(resp.Timeseries))
+	for name, series := range resp.Timeseries {
+		samples := make([]aggregate.Sample, len(series.Samples))
+		for i, s := range series.Samples {
+			samples[i] = aggregate.Sample{Value: s.Value, Unit: s.Unit, Confidence: s.Confidence, Visibility: s.Visibility}
+		}
+		out[name] = samples
+	}
+	return out, nil
+}
+
+func readFrames(paths []string) ([][]byte, error) {
+	frames := make([][]byte, len(paths))
+	for i, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", p, err)
+		}
+		frames[i] = data
+	}
+	return frames, nil
+}
+
+// Shutdown terminates the spawned server, if one was started. Callers
+// should defer this from main() so the Python process doesn't linger
+// after the CLI exits.
+func Shutdown() {
+	mu.Lock()
+	defer mu.Unlock()
+	if inst == nil {
+		return
+	}
+	inst.close()
+	inst = nil
+}
+
+func getClient(ctx context.Context) (*client, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if inst != nil {
+		return inst, nil
+	}
+
+	c, err := spawn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inst = c
+	return inst, nil
+}
+
+func spawn(ctx context.Context) (*client, error) {
+	venvPython := "./venv/bin/python3"
+	if _, err := os.Stat(venvPython); os.IsNotExist(err) {
+		venvPython = "python3"
+	}
+
+	sockPath := filepath.Join(os.TempDir(), fmt.Sprintf("pose-extractor-%d.sock", os.Getpid()))
+	os.Remove(sockPath)
+
+	// Spawned against context.Background(), not ctx: ctx is scoped to
+	// whichever call first triggers the spawn (e.g. a 5-minute timeout
+	// in main_hybrid.go), and exec.CommandContext kills the process
+	// when its context is done. The server needs to outlive any single
+	// request for the rest of the process, same as Shutdown expects.
+	cmd := exec.CommandContext(context.Background(), venvPython, serverPath(), "--socket", sockPath)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting pose extractor server: %w", err)
+	}
+
+	conn, err := dialUnix(ctx, sockPath)
+	if err != nil {
+		cmd.Process.Kill()
+		return nil, fmt.Errorf("dialing pose extractor server: %w", err)
+	}
+
+	rpc := pb.NewPoseExtractorClient(conn)
+	if err := waitHealthy(ctx, rpc); err != nil {
+		conn.Close()
+		cmd.Process.Kill()
+		return nil, fmt.Errorf("pose extractor server never became healthy: %w", err)
+	}
+
+	return &client{cmd: cmd, conn: conn, rpc: rpc, sock: sockPath}, nil
+}
+
+func dialUnix(ctx context.Context, sockPath string) (*grpc.ClientConn, error) {
+	dialer := func(ctx context.Context, addr string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", addr)
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	// MediaPipe model load can take a few seconds; WithBlock plus the
+	// longer timeout here covers that, same as pkg/backend's dialUnix.
+	return grpc.DialContext(dialCtx, sockPath,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(dialer),
+		grpc.WithBlock(),
+	)
+}
+
+func waitHealthy(ctx context.Context, rpc pb.PoseExtractorClient) error {
+	deadline := time.Now().Add(60 * time.Second)
+	for time.Now().Before(deadline) {
+		hctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		resp, err := rpc.Health(hctx, &pb.HealthRequest{})
+		cancel()
+		if err == nil && resp.Ready {
+			return nil
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+	return fmt.Errorf("timed out waiting for health check")
+}
+
+func (c *client) close() error {
+	c.conn.Close()
+	if c.sock != "" {
+		os.Remove(c.sock)
+	}
+	if c.cmd == nil || c.cmd.Process == nil {
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- c.cmd.Wait() }()
+
+	c.cmd.Process.Signal(os.Interrupt)
+	select {
+	case <-done:
+		return nil
+	case <-time.After(5 * time.Second):
+		return c.cmd.Process.Kill()
+	}
+}
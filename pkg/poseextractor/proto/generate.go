@@ -0,0 +1,12 @@
+package proto
+
+// Regenerate pose_extractor.pb.go and pose_extractor_grpc.pb.go (and
+// the Python stubs alongside them) from pose_extractor.proto with
+// protoc and the Go/Python plugins:
+//
+//	go install google.golang.org/protobuf/cmd/protoc-gen-go@latest
+//	go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@latest
+//	python3 -m pip install grpcio-tools
+//
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative pose_extractor.proto
+//go:generate python3 -m grpc_tools.protoc -I. --python_out=. --grpc_python_out=. pose_extractor.proto
@@ -0,0 +1,216 @@
+// Package progress turns a series of already-scored analysis runs
+// (the same {"Title","Data","Icon"} Regions shape, domain scores, and
+// exercise list main.go/generate_report.go build for a single report)
+// into longitudinal trends: per-metric deltas and severity
+// transitions, per-region and overall score series, and which
+// exercise recommendations are new, resolved, or persisting between
+// the oldest and newest run.
+package progress
+
+import "github.com/pranjal-lnct/PostureAnalysis/pkg/aggregate"
+
+// Point is one run's value for a metric or score series.
+type Point struct {
+	Timestamp string  `json:"timestamp"`
+	Value     float64 `json:"value,omitempty"`
+	Severity  string  `json:"severity,omitempty"`
+	Score     int     `json:"score,omitempty"`
+}
+
+// MetricTrend is one metric's series across runs, oldest first.
+type MetricTrend struct {
+	Region       string  `json:"region"`
+	Metric       string  `json:"metric"`
+	Points       []Point `json:"points"`
+	Delta        float64 `json:"delta"`
+	FromSeverity string  `json:"from_severity"`
+	ToSeverity   string  `json:"to_severity"`
+}
+
+// Samples converts a MetricTrend's points into aggregate.Sample so it
+// can be handed to aggregate.Sparkline for the progress report.
+func (t MetricTrend) Samples() []aggregate.Sample {
+	samples := make([]aggregate.Sample, len(t.Points))
+	for i, p := range t.Points {
+		samples[i] = aggregate.Sample{Value: p.Value, Confidence: 1, Visibility: 1}
+	}
+	return samples
+}
+
+// ScoreTrend is a score series across runs: the overall composite
+// (Region == "") or one anatomical region's domain score.
+type ScoreTrend struct {
+	Region string  `json:"region"`
+	Points []Point `json:"points"`
+}
+
+// ExerciseDiff is how the recommended exercise list changed between
+// the oldest and newest run.
+type ExerciseDiff struct {
+	New        []string `json:"new"`
+	Resolved   []string `json:"resolved"`
+	Persisting []string `json:"persisting"`
+}
+
+// Run is one analysis snapshot to align into a History, already
+// scored the same way a single report would be.
+type Run struct {
+	Timestamp    string
+	Regions      []map[string]interface{}
+	PostureScore int
+	DomainScores map[string]int
+	Exercises    []string
+}
+
+// History is the aligned result of Build, ready to persist as
+// history.json and render in progress.html.
+type History struct {
+	Timestamps   []string      `json:"timestamps"`
+	MetricTrends []MetricTrend `json:"metric_trends"`
+	ScoreTrends  []ScoreTrend  `json:"score_trends"`
+	Exercises    ExerciseDiff  `json:"exercises"`
+}
+
+// Build aligns runs, which must already be ordered oldest-first, into
+// a History. A metric or region missing from a given run is simply
+// skipped in that series rather than padded with a zero value.
+func Build(runs []Run) History {
+	h := History{}
+	for _, r := range runs {
+		h.Timestamps = append(h.Timestamps, r.Timestamp)
+	}
+
+	h.MetricTrends = buildMetricTrends(runs)
+	h.ScoreTrends = buildScoreTrends(runs)
+	h.Exercises = diffExercises(runs)
+	return h
+}
+
+func buildMetricTrends(runs []Run) []MetricTrend {
+	type key struct{ region, metric string }
+	order := []key{}
+	seen := map[key]bool{}
+
+	for _, r := range runs {
+		for _, region := range r.Regions {
+			title, _ := region["Title"].(string)
+			dataMap, ok := region["Data"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for metric := range dataMap {
+				k := key{title, metric}
+				if !seen[k] {
+					seen[k] = true
+					order = append(order, k)
+				}
+			}
+		}
+	}
+
+	var trends []MetricTrend
+	for _, k := range order {
+		trend := MetricTrend{Region: k.region, Metric: k.metric}
+		for _, r := range runs {
+			value, severity, ok := lookupMetric(r.Regions, k.region, k.metric)
+			if !ok {
+				continue
+			}
+			trend.Points = append(trend.Points, Point{Timestamp: r.Timestamp, Value: value, Severity: severity})
+		}
+		if len(trend.Points) == 0 {
+			continue
+		}
+		first, last := trend.Points[0], trend.Points[len(trend.Points)-1]
+		trend.Delta = last.Value - first.Value
+		trend.FromSeverity = first.Severity
+		trend.ToSeverity = last.Severity
+		trends = append(trends, trend)
+	}
+	return trends
+}
+
+func lookupMetric(regions []map[string]interface{}, regionTitle, metric string) (value float64, severity string, ok bool) {
+	for _, region := range regions {
+		title, _ := region["Title"].(string)
+		if title != regionTitle {
+			continue
+		}
+		dataMap, dataOk := region["Data"].(map[string]interface{})
+		if !dataOk {
+			return 0, "", false
+		}
+		m, mOk := dataMap[metric].(map[string]interface{})
+		if !mOk {
+			return 0, "", false
+		}
+		v, _ := m["value"].(float64)
+		sev, _ := m["severity"].(string)
+		return v, sev, true
+	}
+	return 0, "", false
+}
+
+func buildScoreTrends(runs []Run) []ScoreTrend {
+	overall := ScoreTrend{Region: ""}
+	for _, r := range runs {
+		overall.Points = append(overall.Points, Point{Timestamp: r.Timestamp, Score: r.PostureScore})
+	}
+	trends := []ScoreTrend{overall}
+
+	order := []string{}
+	seen := map[string]bool{}
+	for _, r := range runs {
+		for region := range r.DomainScores {
+			if !seen[region] {
+				seen[region] = true
+				order = append(order, region)
+			}
+		}
+	}
+
+	for _, region := range order {
+		trend := ScoreTrend{Region: region}
+		for _, r := range runs {
+			score, ok := r.DomainScores[region]
+			if !ok {
+				continue
+			}
+			trend.Points = append(trend.Points, Point{Timestamp: r.Timestamp, Score: score})
+		}
+		trends = append(trends, trend)
+	}
+	return trends
+}
+
+func diffExercises(runs []Run) ExerciseDiff {
+	var diff ExerciseDiff
+	if len(runs) == 0 {
+		return diff
+	}
+
+	oldest := toSet(runs[0].Exercises)
+	newest := toSet(runs[len(runs)-1].Exercises)
+
+	for _, name := range runs[len(runs)-1].Exercises {
+		if oldest[name] {
+			diff.Persisting = append(diff.Persisting, name)
+		} else {
+			diff.New = append(diff.New, name)
+		}
+	}
+	for _, name := range runs[0].Exercises {
+		if !newest[name] {
+			diff.Resolved = append(diff.Resolved, name)
+		}
+	}
+	return diff
+}
+
+func toSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, n := range names {
+		set[n] = true
+	}
+	return set
+}
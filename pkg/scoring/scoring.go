@@ -0,0 +1,241 @@
+// Package scoring derives region severities and a numeric posture
+// score directly from measured PoseMetrics, before the LLM is ever
+// called, against clinically-sourced cutoffs instead of whatever
+// severity the model happens to write for a region. The LLM still
+// produces the narrative; Score's output is meant to be injected into
+// its prompt as ground truth so it interprets these severities rather
+// than inventing its own, and the same metrics plus the same
+// thresholds.yaml always reproduce the same score.
+package scoring
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Metric is one measured value to score. It mirrors main_hybrid.go's
+// Metric shape without importing package main, which owns that type
+// (the same convention pkg/poseextractor.Metric follows).
+type Metric struct {
+	Value      float64
+	Unit       string
+	Confidence float64
+}
+
+// Metrics mirrors main_hybrid.go's PoseMetrics shape field-for-field.
+type Metrics struct {
+	CraniovertebralAngle *Metric
+	ForwardHeadPosture   *Metric
+	ShoulderHeightDelta  *Metric
+	ThoracicKyphosis     *Metric
+	KneeValgusVarus      *Metric
+	FootProgressionAngle *Metric
+}
+
+// Range is one severity band: a metric's absolute value falls in it
+// when Min <= value (if Min is set) and value < Max (if Max is set).
+// A nil bound means unbounded on that side.
+type Range struct {
+	Min *float64 `yaml:"min,omitempty"`
+	Max *float64 `yaml:"max,omitempty"`
+}
+
+func (r Range) contains(v float64) bool {
+	if r.Min != nil && v < *r.Min {
+		return false
+	}
+	if r.Max != nil && v >= *r.Max {
+		return false
+	}
+	return true
+}
+
+// MetricThresholds is the severity ladder for one scored metric, in
+// absolute-value terms (e.g. shoulder_height_delta's cutoffs apply
+// regardless of which shoulder sits higher).
+type MetricThresholds struct {
+	Mild     Range `yaml:"mild"`
+	Moderate Range `yaml:"moderate"`
+	Severe   Range `yaml:"severe"`
+}
+
+// severity classifies |value| against the ladder, checking severe
+// first since bands are allowed to share an open-ended boundary (e.g.
+// moderate's "max 3" and severe's "min 3, no max").
+func (m MetricThresholds) severity(value float64) string {
+	v := value
+	if v < 0 {
+		v = -v
+	}
+	switch {
+	case m.Severe.contains(v):
+		return "severe"
+	case m.Moderate.contains(v):
+		return "moderate"
+	case m.Mild.contains(v):
+		return "mild"
+	default:
+		return "normal"
+	}
+}
+
+// Thresholds maps each scored metric (keyed the same way PoseMetrics'
+// JSON tags are, e.g. "craniovertebral_angle") to its severity ladder.
+type Thresholds struct {
+	Metrics map[string]MetricThresholds `yaml:"metrics"`
+}
+
+// Path resolves the thresholds.yaml to load: flagValue, then
+// THRESHOLDS_FILE, then "thresholds.yaml".
+func Path(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if v := os.Getenv("THRESHOLDS_FILE"); v != "" {
+		return v
+	}
+	return "thresholds.yaml"
+}
+
+// Load reads and parses a thresholds.yaml. A missing file isn't an
+// error - callers get DefaultThresholds() instead, so a fresh
+// installation without a custom file still scores deterministically.
+func Load(path string) (Thresholds, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return DefaultThresholds(), nil
+	}
+	if err != nil {
+		return Thresholds{}, fmt.Errorf("reading thresholds %s: %w", path, err)
+	}
+
+	var cfg Thresholds
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return Thresholds{}, fmt.Errorf("parsing thresholds %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func f64(v float64) *float64 { return &v }
+
+// DefaultThresholds are published clinical cutoffs used when no
+// thresholds.yaml is found or a metric is missing from one: a
+// craniovertebral angle under ~50deg indicates forward head posture,
+// a shoulder height delta over ~2cm indicates asymmetry, etc.
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		Metrics: map[string]MetricThresholds{
+			// Lower angle = more forward head posture, so this ladder
+			// counts down from normal instead of up like the others.
+			"craniovertebral_angle": {
+				Mild:     Range{Min: f64(45), Max: f64(50)},
+				Moderate: Range{Min: f64(40), Max: f64(45)},
+				Severe:   Range{Max: f64(40)},
+			},
+			"forward_head_posture": {
+				Mild:     Range{Min: f64(0), Max: f64(5)},
+				Moderate: Range{Min: f64(5), Max: f64(10)},
+				Severe:   Range{Min: f64(10)},
+			},
+			"shoulder_height_delta": {
+				Mild:     Range{Min: f64(1), Max: f64(2)},
+				Moderate: Range{Min: f64(2), Max: f64(3)},
+				Severe:   Range{Min: f64(3)},
+			},
+			"thoracic_kyphosis": {
+				Mild:     Range{Min: f64(40), Max: f64(50)},
+				Moderate: Range{Min: f64(50), Max: f64(60)},
+				Severe:   Range{Min: f64(60)},
+			},
+			"knee_valgus_varus": {
+				Mild:     Range{Min: f64(5), Max: f64(10)},
+				Moderate: Range{Min: f64(10), Max: f64(15)},
+				Severe:   Range{Min: f64(15)},
+			},
+			"foot_progression_angle": {
+				Mild:     Range{Min: f64(10), Max: f64(15)},
+				Moderate: Range{Min: f64(15), Max: f64(20)},
+				Severe:   Range{Min: f64(20)},
+			},
+		},
+	}
+}
+
+// RegionSeverity is the deterministic severity finding for one scored
+// metric.
+type RegionSeverity struct {
+	Metric     string  `json:"metric"`
+	Region     string  `json:"region"`
+	Value      float64 `json:"value"`
+	Unit       string  `json:"unit"`
+	Severity   string  `json:"severity"`
+	Confidence float64 `json:"confidence"`
+}
+
+// Evidence is everything Score decided, kept as an audit trail
+// alongside (and independent of) the LLM's narrative.
+type Evidence struct {
+	Thresholds Thresholds       `json:"thresholds"`
+	Regions    []RegionSeverity `json:"regions"`
+}
+
+type namedMetric struct {
+	name   string
+	region string
+	metric *Metric
+}
+
+// Score classifies every non-nil field of metrics against cfg and
+// returns the per-metric severities, a 0-100 total (100 minus
+// 15/10/5 per severe/moderate/mild finding - the same point values
+// buildRegionsAndScore used before this package existed), and the
+// evidence trail behind both. It's a pure function: the same metrics
+// and cfg always produce the same result.
+func Score(metrics Metrics, cfg Thresholds) ([]RegionSeverity, int, Evidence) {
+	named := []namedMetric{
+		{"craniovertebral_angle", "Head & Neck", metrics.CraniovertebralAngle},
+		{"forward_head_posture", "Head & Neck", metrics.ForwardHeadPosture},
+		{"shoulder_height_delta", "Shoulders & Scapulae", metrics.ShoulderHeightDelta},
+		{"thoracic_kyphosis", "Spine", metrics.ThoracicKyphosis},
+		{"knee_valgus_varus", "Lower Extremities", metrics.KneeValgusVarus},
+		{"foot_progression_angle", "Ankles & Feet", metrics.FootProgressionAngle},
+	}
+
+	score := 100
+	var regions []RegionSeverity
+	for _, nm := range named {
+		if nm.metric == nil {
+			continue
+		}
+		ladder, ok := cfg.Metrics[nm.name]
+		if !ok {
+			continue
+		}
+
+		sev := ladder.severity(nm.metric.Value)
+		regions = append(regions, RegionSeverity{
+			Metric:     nm.name,
+			Region:     nm.region,
+			Value:      nm.metric.Value,
+			Unit:       nm.metric.Unit,
+			Severity:   sev,
+			Confidence: nm.metric.Confidence,
+		})
+
+		switch sev {
+		case "severe":
+			score -= 15
+		case "moderate":
+			score -= 10
+		case "mild":
+			score -= 5
+		}
+	}
+	if score < 0 {
+		score = 0
+	}
+
+	return regions, score, Evidence{Thresholds: cfg, Regions: regions}
+}
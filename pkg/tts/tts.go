@@ -0,0 +1,169 @@
+// Package tts narrates a generated report: it turns the clinical
+// reasoning, priority areas and exercise recommendations into a short
+// script and synthesizes it to audio, so clinicians can hand patients
+// something to listen to instead of just read.
+package tts
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// Provider synthesizes narration text to audio bytes, returning the
+// file extension the bytes should be saved with (".mp3" or ".wav").
+type Provider interface {
+	Synthesize(ctx context.Context, script string) (audio []byte, ext string, err error)
+}
+
+// New builds a Provider by name, as read from TTS_PROVIDER. Supported
+// names are "openai", "gemini" and "piper" (a bundled local voice, for
+// fully offline narration).
+func New(name string) (Provider, error) {
+	switch name {
+	case "openai":
+		return &openAIProvider{}, nil
+	case "gemini":
+		return &geminiProvider{}, nil
+	case "piper":
+		return &piperProvider{}, nil
+	default:
+		return nil, fmt.Errorf("unknown TTS_PROVIDER %q (want openai, gemini, or piper)", name)
+	}
+}
+
+// openAIProvider uses OpenAI's /v1/audio/speech endpoint.
+type openAIProvider struct{}
+
+func (p *openAIProvider) Synthesize(ctx context.Context, script string) ([]byte, string, error) {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		return nil, "", fmt.Errorf("OPENAI_API_KEY not set")
+	}
+
+	voice := os.Getenv("OPENAI_TTS_VOICE")
+	if voice == "" {
+		voice = "alloy"
+	}
+
+	client := openai.NewClient(apiKey)
+	resp, err := client.CreateSpeech(ctx, openai.CreateSpeechRequest{
+		Model:          openai.TTSModel1,
+		Input:          script,
+		Voice:          openai.SpeechVoice(voice),
+		ResponseFormat: openai.SpeechResponseFormatMp3,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("OpenAI speech synthesis: %w", err)
+	}
+	defer resp.Close()
+
+	audio, err := io.ReadAll(resp)
+	if err != nil {
+		return nil, "", err
+	}
+	return audio, ".mp3", nil
+}
+
+// geminiProvider uses Gemini's text-to-speech REST endpoint directly,
+// since the genai SDK vendored elsewhere in this repo doesn't expose
+// audio output yet.
+type geminiProvider struct{}
+
+func (p *geminiProvider) Synthesize(ctx context.Context, script string) ([]byte, string, error) {
+	apiKey := os.Getenv("GOOGLE_API_KEY")
+	if apiKey == "" {
+		apiKey = os.Getenv("GEMINI_API_KEY")
+	}
+	if apiKey == "" {
+		return nil, "", fmt.Errorf("GOOGLE_API_KEY not set")
+	}
+
+	modelName := os.Getenv("GEMINI_TTS_MODEL")
+	if modelName == "" {
+		modelName = "gemini-2.5-flash-preview-tts"
+	}
+
+	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:generateContent?key=%s", modelName, apiKey)
+	body := fmt.Sprintf(`{"contents":[{"parts":[{"text":%q}]}],"generationConfig":{"responseModalities":["AUDIO"]}}`, script)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("Gemini TTS request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var parsed struct {
+		Candidates []struct {
+			Content struct {
+				Parts []struct {
+					InlineData struct {
+						Data string `json:"data"`
+					} `json:"inlineData"`
+				} `json:"parts"`
+			} `json:"content"`
+		} `json:"candidates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, "", fmt.Errorf("parsing Gemini TTS response: %w", err)
+	}
+	if len(parsed.Candidates) == 0 || len(parsed.Candidates[0].Content.Parts) == 0 {
+		return nil, "", fmt.Errorf("Gemini TTS response had no audio part")
+	}
+
+	audio, err := base64.StdEncoding.DecodeString(parsed.Candidates[0].Content.Parts[0].InlineData.Data)
+	if err != nil {
+		return nil, "", fmt.Errorf("decoding Gemini TTS audio: %w", err)
+	}
+	return audio, ".wav", nil
+}
+
+// piperProvider shells out to a bundled Piper voice for fully offline
+// synthesis, mirroring how extractPoseMetrics shells out to a local
+// Python tool rather than calling a cloud API.
+type piperProvider struct{}
+
+func (p *piperProvider) Synthesize(ctx context.Context, script string) ([]byte, string, error) {
+	piperBin := os.Getenv("PIPER_BIN")
+	if piperBin == "" {
+		piperBin = "piper"
+	}
+	voiceModel := os.Getenv("PIPER_VOICE")
+	if voiceModel == "" {
+		return nil, "", fmt.Errorf("PIPER_VOICE not set (path to a .onnx voice model)")
+	}
+
+	outFile, err := os.CreateTemp("", "posture-narration-*.wav")
+	if err != nil {
+		return nil, "", err
+	}
+	outPath := outFile.Name()
+	outFile.Close()
+	defer os.Remove(outPath)
+
+	cmd := exec.CommandContext(ctx, piperBin, "--model", voiceModel, "--output_file", outPath)
+	cmd.Stdin = strings.NewReader(script)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, "", fmt.Errorf("piper synthesis failed: %w\n%s", err, output)
+	}
+
+	audio, err := os.ReadFile(outPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading piper output: %w", err)
+	}
+	return audio, ".wav", nil
+}
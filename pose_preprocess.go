@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/pose"
+)
+
+// poseView is one view image's keypoint extraction, kept alongside its
+// label so prompt text and overlay filenames read naturally ("Front
+// View", not "front").
+type poseView struct {
+	label  string
+	result *pose.Result
+	angles pose.Angles
+}
+
+// preprocessPose runs keypoint extraction on all four view images
+// through the configured pose backend and saves an annotated overlay
+// for each next to analysis.json in outputDir. It returns an empty
+// slice (not an error) when no pose backend is configured or reachable
+// so analysis can proceed without it, the same "best effort, warn and
+// continue" convention runOllama/runBackend use for optional features.
+func preprocessPose(ctx context.Context, outputDir, poseBackendURLFlag, frontPath, leftPath, rightPath, backPath string) []poseView {
+	baseURL := pose.BackendURL(poseBackendURLFlag)
+	if baseURL == "" {
+		return nil
+	}
+	preprocessor := pose.New(baseURL)
+
+	views := []struct {
+		label string
+		path  string
+	}{
+		{"Front View", frontPath},
+		{"Left Side View", leftPath},
+		{"Right Side View", rightPath},
+		{"Back View", backPath},
+	}
+
+	var out []poseView
+	for _, v := range views {
+		result, err := preprocessor.Process(ctx, v.path)
+		if err != nil {
+			log.Printf("Warning: pose preprocessing skipped for %s: %v", v.label, err)
+			continue
+		}
+
+		if overlay, err := pose.DrawOverlay(v.path, result); err != nil {
+			log.Printf("Warning: could not render pose overlay for %s: %v", v.label, err)
+		} else {
+			overlayName := strings.ToLower(strings.ReplaceAll(v.label, " ", "_")) + "_annotated.png"
+			if err := os.WriteFile(filepath.Join(outputDir, overlayName), overlay, 0644); err != nil {
+				log.Printf("Warning: could not save pose overlay %s: %v", overlayName, err)
+			}
+		}
+
+		out = append(out, poseView{label: v.label, result: result, angles: pose.ComputeAngles(result)})
+	}
+	return out
+}
+
+// posePromptBlock renders the extracted landmarks and derived angles
+// as plain text so they ride alongside the images in the same prompt,
+// giving the model numeric ground truth instead of requiring it to
+// estimate pixel positions itself.
+func posePromptBlock(views []poseView) string {
+	var b strings.Builder
+	b.WriteString("Geometric keypoint measurements (extracted independently of this model, use as ground truth where present):\n")
+	for _, v := range views {
+		fmt.Fprintf(&b, "- %s: craniovertebral angle %.1f deg, shoulder height delta %.3f, pelvic obliquity %.3f, knee valgus %.3f\n",
+			v.label, v.angles.CraniovertebralAngle, v.angles.ShoulderHeightDelta, v.angles.PelvicObliquity, v.angles.KneeValgus)
+	}
+	return b.String()
+}
+
+// poseLandmarksForReport shapes the raw per-view landmarks into
+// something JSON-serializable for analysisData, so the HTML report
+// and any later comparison can see exactly what the preprocessor
+// measured.
+func poseLandmarksForReport(views []poseView) map[string]interface{} {
+	out := make(map[string]interface{}, len(views))
+	for _, v := range views {
+		b, err := json.Marshal(v.result.Landmarks)
+		if err != nil {
+			continue
+		}
+		var landmarks map[string]interface{}
+		json.Unmarshal(b, &landmarks)
+		out[v.label] = map[string]interface{}{
+			"landmarks": landmarks,
+			"angles": map[string]float64{
+				"craniovertebral_angle": v.angles.CraniovertebralAngle,
+				"shoulder_height_delta": v.angles.ShoulderHeightDelta,
+				"pelvic_obliquity":      v.angles.PelvicObliquity,
+				"knee_valgus":           v.angles.KneeValgus,
+			},
+		}
+	}
+	return out
+}
+
+// crossCheckPoseAgainstRegions compares the LLM's reported severities
+// against the geometric angles measured from keypoints, and flags
+// regions where they disagree by more than a clinically meaningful
+// margin so a reviewer knows to treat the LLM's narrative with extra
+// skepticism there. It does not override the LLM's severity; it only
+// annotates it.
+func crossCheckPoseAgainstRegions(views []poseView, analysisData map[string]interface{}) {
+	front := findView(views, "Front View")
+	if front == nil {
+		return
+	}
+
+	headNeck, ok := analysisData["head_neck"].(map[string]interface{})
+	if ok {
+		flagDisagreement(headNeck, "forward_head_posture", front.angles.CraniovertebralAngle < 50,
+			fmt.Sprintf("craniovertebral angle %.1f deg suggests forward head posture", front.angles.CraniovertebralAngle))
+	}
+	if shoulders, ok := analysisData["shoulders"].(map[string]interface{}); ok {
+		flagDisagreement(shoulders, "shoulder_height_delta", math.Abs(front.angles.ShoulderHeightDelta) > 0.02,
+			fmt.Sprintf("shoulder height delta %.3f suggests asymmetry", front.angles.ShoulderHeightDelta))
+	}
+	if pelvis, ok := analysisData["pelvis"].(map[string]interface{}); ok {
+		flagDisagreement(pelvis, "pelvic_obliquity", math.Abs(front.angles.PelvicObliquity) > 0.02,
+			fmt.Sprintf("pelvic obliquity %.3f suggests asymmetry", front.angles.PelvicObliquity))
+	}
+}
+
+func findView(views []poseView, label string) *poseView {
+	for i := range views {
+		if views[i].label == label {
+			return &views[i]
+		}
+	}
+	return nil
+}
+
+// flagDisagreement downgrades confidence on analysisData[key] when the
+// geometric signal (geometricFlagsIssue) disagrees with whether the
+// LLM reported any severity at all for that metric.
+func flagDisagreement(region map[string]interface{}, key string, geometricFlagsIssue bool, reason string) {
+	metric, ok := region[key].(map[string]interface{})
+	if !ok {
+		return
+	}
+	sev, _ := metric["severity"].(string)
+	llmFlagsIssue := sev != "" && sev != "normal" && sev != "none"
+
+	if llmFlagsIssue != geometricFlagsIssue {
+		metric["geometric_confidence"] = "low"
+		metric["geometric_note"] = reason
+	}
+}
@@ -0,0 +1,346 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/exercises"
+)
+
+// runServe turns the CLI into a long-running HTTP service. It exposes
+// an OpenAI-compatible /v1/chat/completions endpoint (so existing
+// OpenAI SDKs can be pointed at it) plus a domain-specific
+// /v1/posture/analyze that accepts the four view images directly. Both
+// reuse runGemini/runOpenAI and buildPostureSchema so the analysis
+// pipeline stays identical to the one-shot CLI, and every request is
+// persisted into output/<timestamp> the same way.
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("address", ":8090", "Address to listen on")
+	fs.Parse(args)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/chat/completions", handleChatCompletions)
+	mux.HandleFunc("/v1/posture/analyze", handlePostureAnalyze)
+
+	fmt.Printf("Serving posture analysis API on %s\n", *addr)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatCompletionRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+}
+
+type chatCompletionChoice struct {
+	Index   int         `json:"index"`
+	Message chatMessage `json:"message"`
+}
+
+type chatCompletionResponse struct {
+	ID      string                 `json:"id"`
+	Object  string                 `json:"object"`
+	Created int64                  `json:"created"`
+	Model   string                 `json:"model"`
+	Choices []chatCompletionChoice `json:"choices"`
+}
+
+// handleChatCompletions implements a bare-bones OpenAI-compatible
+// chat endpoint: the last user message is treated as the posture
+// analysis prompt and run through the provider set in AI_PROVIDER (no
+// images; use /v1/posture/analyze for that).
+func handleChatCompletions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req chatCompletionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	promptText := lastUserMessage(req.Messages)
+	if promptText == "" {
+		http.Error(w, "no user message found", http.StatusBadRequest)
+		return
+	}
+
+	provider := os.Getenv("AI_PROVIDER")
+	if provider == "" {
+		provider = "gemini"
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Minute)
+	defer cancel()
+
+	var resultText string
+	var err error
+	if provider == "openai" {
+		resultText, err = runOpenAI(ctx, promptText, "front.jpg", "left.jpg", "right.jpg", "back.jpg")
+	} else {
+		resultText, err = runGemini(ctx, promptText, "front.jpg", "left.jpg", "right.jpg", "back.jpg")
+	}
+	if err != nil {
+		http.Error(w, "analysis failed: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	resp := chatCompletionResponse{
+		ID:      fmt.Sprintf("chatcmpl-%d", time.Now().UnixNano()),
+		Object:  "chat.completion",
+		Created: time.Now().Unix(),
+		Model:   req.Model,
+		Choices: []chatCompletionChoice{
+			{Index: 0, Message: chatMessage{Role: "assistant", Content: resultText}},
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func lastUserMessage(messages []chatMessage) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			return messages[i].Content
+		}
+	}
+	return ""
+}
+
+// sseEvent is one phase emitted while an analyze request is in
+// flight, so a browser can show progress during the (up to 10 minute)
+// multimodal call instead of staring at a spinner.
+type sseEvent struct {
+	name string
+	data string
+}
+
+func writeSSE(w http.ResponseWriter, flusher http.Flusher, ev sseEvent) {
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.name, ev.data)
+	flusher.Flush()
+}
+
+// handlePostureAnalyze accepts the same multipart upload as the `web`
+// upload form (front/left/right/back), runs the identical pipeline
+// used by main() and the web server, and streams SSE progress events
+// if the client sent `Accept: text/event-stream`.
+func handlePostureAnalyze(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	stream := strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+	var flusher http.Flusher
+	if stream {
+		f, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+		flusher = f
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+	}
+
+	if err := r.ParseMultipartForm(50 << 20); err != nil {
+		http.Error(w, "could not parse multipart form", http.StatusBadRequest)
+		return
+	}
+
+	outputBase := os.Getenv("OUTPUT_DIR")
+	if outputBase == "" {
+		outputBase = "output"
+	}
+	timestamp := time.Now().Format("2006-01-02_15-04-05")
+	outputDir := filepath.Join(outputBase, timestamp)
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		http.Error(w, "could not create output directory", http.StatusInternalServerError)
+		return
+	}
+
+	paths := make(map[string]string)
+	for _, view := range []string{"front", "left", "right", "back"} {
+		file, header, err := r.FormFile(view)
+		if err != nil {
+			http.Error(w, "missing "+view+" image", http.StatusBadRequest)
+			return
+		}
+		savePath := filepath.Join(outputDir, view+filepath.Ext(header.Filename))
+		dst, err := os.Create(savePath)
+		if err != nil {
+			file.Close()
+			http.Error(w, "could not save "+view+" image", http.StatusInternalServerError)
+			return
+		}
+		io.Copy(dst, file)
+		dst.Close()
+		file.Close()
+		paths[view] = savePath
+	}
+	if stream {
+		writeSSE(w, flusher, sseEvent{"upload_saved", outputDir})
+	}
+
+	promptFile := os.Getenv("PROMPT_FILE")
+	if promptFile == "" {
+		promptFile = "prompt.txt"
+	}
+	promptBytes, err := os.ReadFile(promptFile)
+	if err != nil {
+		http.Error(w, "could not read prompt file", http.StatusInternalServerError)
+		return
+	}
+
+	provider := os.Getenv("AI_PROVIDER")
+	if provider == "" {
+		provider = "gemini"
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Minute)
+	defer cancel()
+
+	if stream {
+		writeSSE(w, flusher, sseEvent{"llm_request_sent", provider})
+	}
+
+	var resultText string
+	if provider == "openai" {
+		resultText, err = runOpenAI(ctx, string(promptBytes), paths["front"], paths["left"], paths["right"], paths["back"])
+	} else {
+		resultText, err = runGemini(ctx, string(promptBytes), paths["front"], paths["left"], paths["right"], paths["back"])
+	}
+	if err != nil {
+		http.Error(w, "analysis failed: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	if err := os.WriteFile(filepath.Join(outputDir, "analysis.json"), []byte(resultText), 0644); err != nil {
+		log.Printf("Warning: could not save analysis.json: %v", err)
+	}
+
+	var analysisData map[string]interface{}
+	cleanJson := strings.TrimSpace(resultText)
+	cleanJson = strings.TrimPrefix(cleanJson, "```json")
+	cleanJson = strings.TrimPrefix(cleanJson, "```")
+	cleanJson = strings.TrimSuffix(cleanJson, "```")
+
+	if err := json.Unmarshal([]byte(cleanJson), &analysisData); err != nil {
+		http.Error(w, "failed to parse analysis", http.StatusInternalServerError)
+		return
+	}
+	if stream {
+		writeSSE(w, flusher, sseEvent{"schema_validated", ""})
+	}
+
+	analysisData["input_images"] = map[string]string{
+		"front": filepath.Base(paths["front"]),
+		"left":  filepath.Base(paths["left"]),
+		"right": filepath.Base(paths["right"]),
+		"back":  filepath.Base(paths["back"]),
+	}
+	buildRegionsAndScore(analysisData)
+	catalog, err := exercises.Load(exercises.Dir(""))
+	if err != nil {
+		log.Printf("Warning: could not load exercise catalog: %v", err)
+		catalog = &exercises.Catalog{}
+	}
+	analysisData["Exercises"] = catalog.Recommend(analysisData)
+	if stream {
+		writeSSE(w, flusher, sseEvent{"score_computed", fmt.Sprintf("%v", analysisData["PostureScore"])})
+	}
+
+	templateFile := os.Getenv("TEMPLATE_FILE")
+	if templateFile == "" {
+		templateFile = "template.html"
+	}
+	generateHTMLReport(outputDir, analysisData, templateFile)
+
+	reportURL := "/report/" + timestamp
+	if stream {
+		writeSSE(w, flusher, sseEvent{"report_ready", reportURL})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"analysis":   analysisData,
+		"report_url": reportURL,
+	})
+}
+
+// buildRegionsAndScore mirrors the block main() inlines after parsing
+// the model's JSON: it derives Regions/PostureScore so serve's JSON
+// response and report.html match the CLI's output exactly.
+func buildRegionsAndScore(analysisData map[string]interface{}) {
+	icons := map[string]string{
+		"Head & Neck":          "M16 7a4 4 0 11-8 0 4 4 0 018 0zM12 14a7 7 0 00-7 7h14a7 7 0 00-7-7z",
+		"Shoulders & Scapulae": "M19 11H5m14 0a2 2 0 012 2v6a2 2 0 01-2 2H5a2 2 0 01-2-2v-6a2 2 0 012-2m14 0V9a2 2 0 00-2-2M5 11V9a2 2 0 012-2m0 0V5a2 2 0 012-2h6a2 2 0 012 2v2M7 7h10",
+		"Spine":                "M4 6h16M4 10h16M4 14h16M4 18h16",
+		"Pelvis & Hips":        "M12 6V4m0 2a2 2 0 100 4m0-4a2 2 0 110 4m-6 8a2 2 0 100-4m0 4a2 2 0 110-4m0 4v2m0-6V4m6 6v10m6-2a2 2 0 100-4m0 4a2 2 0 110-4m0 4v2m0-6V4",
+		"Lower Extremities":    "M13.828 10.172a4 4 0 00-5.656 0l-4 4a4 4 0 105.656 5.656l1.102-1.101m-.758-4.899a4 4 0 005.656 0l4-4a4 4 0 00-5.656-5.656l-1.1 1.1",
+		"Ankles & Feet":        "M3.055 11H5a2 2 0 012 2v1a2 2 0 002 2 2 2 0 012 2v2.945M8 3.935V5.5A2.5 2.5 0 0010.5 8h.5a2 2 0 012 2 2 2 0 104 0 2 2 0 012-2h1.064M15 20.488V18a2 2 0 012-2h3.064",
+	}
+
+	regions := []map[string]interface{}{
+		{"Title": "Head & Neck", "Data": analysisData["head_neck"], "Icon": icons["Head & Neck"]},
+		{"Title": "Shoulders & Scapulae", "Data": analysisData["shoulders"], "Icon": icons["Shoulders & Scapulae"]},
+		{"Title": "Spine", "Data": analysisData["spine"], "Icon": icons["Spine"]},
+		{"Title": "Pelvis & Hips", "Data": analysisData["pelvis"], "Icon": icons["Pelvis & Hips"]},
+		{"Title": "Lower Extremities", "Data": analysisData["lower_extremities"], "Icon": icons["Lower Extremities"]},
+		{"Title": "Ankles & Feet", "Data": analysisData["ankles_feet"], "Icon": icons["Ankles & Feet"]},
+	}
+
+	if headNeck, ok := analysisData["head_neck"].(map[string]interface{}); ok {
+		if globalAlign, ok := analysisData["global_alignment"].(map[string]interface{}); ok {
+			if fhp, ok := globalAlign["forward_head_posture"]; ok {
+				headNeck["forward_head_posture"] = fhp
+			}
+		}
+	}
+
+	score := 100
+	for _, region := range regions {
+		dataMap, ok := region["Data"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, v := range dataMap {
+			if metric, ok := v.(map[string]interface{}); ok {
+				sev, _ := metric["severity"].(string)
+				switch sev {
+				case "severe":
+					score -= 15
+				case "moderate":
+					score -= 10
+				case "mild":
+					score -= 5
+				}
+			}
+		}
+	}
+	if score < 0 {
+		score = 0
+	}
+
+	analysisData["PostureScore"] = score
+	analysisData["Regions"] = regions
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/scoring"
+)
+
+// runHybridServe turns the hybrid pipeline (measured MediaPipe metrics
+// plus LLM interpretation) into a long-running HTTP service. It
+// exposes POST /v1/analyze, which accepts the same four view images
+// as the one-shot CLI above plus an optional height, and runs the
+// identical extractPoseMetrics -> getLLMInterpretation -> saveResults
+// pipeline; and GET /v1/reports/{id}/... to fetch the report.html and
+// annotated images that pipeline wrote out.
+func runHybridServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("address", ":8091", "Address to listen on")
+	uploadLimitMB := fs.Int64("upload-limit-mb", 50, "Maximum multipart upload size, in megabytes")
+	thresholdsPath := fs.String("thresholds", "", "Path to a thresholds.yaml of severity cutoffs (overrides THRESHOLDS_FILE, default thresholds.yaml)")
+	fs.Parse(args)
+
+	outputBase := os.Getenv("OUTPUT_DIR")
+	if outputBase == "" {
+		outputBase = "output"
+	}
+
+	thresholds, err := scoring.Load(scoring.Path(*thresholdsPath))
+	if err != nil {
+		log.Fatalf("Error loading thresholds: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/analyze", handleHybridAnalyze(*uploadLimitMB<<20, outputBase, thresholds))
+	mux.HandleFunc("/v1/reports/", handleHybridReport(outputBase))
+
+	fmt.Printf("Serving hybrid posture analysis API on %s\n", *addr)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
+
+// handleHybridAnalyze accepts a multipart upload of front/left/right/back
+// images (plus an optional "height" field, in cm) and a per-request
+// output directory keyed by a UUID, so concurrent requests never share
+// state the way the one-shot CLI's single output/<timestamp> dir would.
+func handleHybridAnalyze(maxUploadBytes int64, outputBase string, thresholds scoring.Thresholds) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := r.ParseMultipartForm(maxUploadBytes); err != nil {
+			http.Error(w, "could not parse multipart form", http.StatusBadRequest)
+			return
+		}
+
+		id := newRequestID()
+		outputDir := filepath.Join(outputBase, id)
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			http.Error(w, "could not create output directory", http.StatusInternalServerError)
+			return
+		}
+
+		paths := make(map[string]string)
+		for _, view := range []string{"front", "left", "right", "back"} {
+			file, header, err := r.FormFile(view)
+			if err != nil {
+				http.Error(w, "missing "+view+" image", http.StatusBadRequest)
+				return
+			}
+			savePath := filepath.Join(outputDir, view+filepath.Ext(header.Filename))
+			dst, err := os.Create(savePath)
+			if err != nil {
+				file.Close()
+				http.Error(w, "could not save "+view+" image", http.StatusInternalServerError)
+				return
+			}
+			_, copyErr := io.Copy(dst, file)
+			dst.Close()
+			file.Close()
+			if copyErr != nil {
+				http.Error(w, "could not save "+view+" image", http.StatusInternalServerError)
+				return
+			}
+			paths[view] = savePath
+		}
+
+		var height float64
+		if h := r.FormValue("height"); h != "" {
+			if parsed, err := strconv.ParseFloat(h, 64); err == nil {
+				height = parsed
+			}
+		}
+
+		provider := os.Getenv("AI_PROVIDER")
+		if provider == "" {
+			provider = "gemini"
+		}
+
+		metrics, err := extractPoseMetrics(paths["front"], paths["left"], paths["right"], paths["back"], height, outputDir)
+		if err != nil {
+			http.Error(w, "pose extraction failed: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		regionSeverities, deterministicScore, evidence := scoring.Score(toScoringMetrics(metrics), thresholds)
+		interpretation := getLLMInterpretation(metrics, regionSeverities, provider, paths["front"], paths["left"], paths["right"], paths["back"])
+		saveResults(outputDir, metrics, interpretation, paths["front"], paths["left"], paths["right"], paths["back"], nil, deterministicScore, evidence)
+
+		var analysisData map[string]interface{}
+		json.Unmarshal([]byte(interpretation), &analysisData)
+		buildRegionsAndScore(analysisData)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"metrics":        metrics,
+			"interpretation": analysisData,
+			"score":          analysisData["PostureScore"],
+			"report_url":     "/v1/reports/" + id,
+		})
+	}
+}
+
+// handleHybridReport serves report.html (or, with a trailing path
+// segment, a sibling file such as front_annotated.jpg) out of a given
+// request's output directory.
+func handleHybridReport(outputBase string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rest := strings.TrimPrefix(r.URL.Path, "/v1/reports/")
+		if rest == "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		id, file := rest, "report.html"
+		if idx := strings.Index(rest, "/"); idx >= 0 {
+			id, file = rest[:idx], rest[idx+1:]
+		}
+		if file == "" {
+			file = "report.html"
+		}
+
+		// filepath.Clean on a rooted path collapses any "../" segments
+		// before it's joined back onto outputBase, so a crafted id or
+		// file can't escape the output directory.
+		safeID := filepath.Clean(string(filepath.Separator) + id)
+		safeFile := filepath.Clean(string(filepath.Separator) + file)
+		http.ServeFile(w, r, filepath.Join(outputBase, safeID, safeFile))
+	}
+}
+
+// newRequestID generates a UUIDv4 used to key a single /v1/analyze
+// request's output directory.
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand.Read on a sane OS doesn't fail; this is just a
+		// defensive fallback so a request never crashes on it.
+		return fmt.Sprintf("%x", []byte(fmt.Sprintf("%p", b)))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -3,13 +3,16 @@ package main
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strings"
 
 	"github.com/google/generative-ai-go/genai"
 	openai "github.com/sashabaranov/go-openai"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
@@ -79,6 +82,83 @@ func runGemini(ctx context.Context, promptText, frontPath, leftPath, rightPath,
 	return result.String()
 }
 
+// runGeminiStream mirrors runGemini but hands each partial chunk of
+// text to onChunk as it arrives instead of blocking for the whole
+// response, so the SSE pipeline has something to emit for llm_chunk
+// before the model finishes.
+func runGeminiStream(ctx context.Context, promptText, frontPath, leftPath, rightPath, backPath string, onChunk func(string)) (string, error) {
+	apiKey := os.Getenv("GOOGLE_API_KEY")
+	if apiKey == "" {
+		apiKey = os.Getenv("GEMINI_API_KEY")
+	}
+	if apiKey == "" {
+		return "", fmt.Errorf("GOOGLE_API_KEY not set")
+	}
+
+	modelName := os.Getenv("GEMINI_MODEL")
+	if modelName == "" {
+		modelName = "gemini-3-flash-preview"
+	}
+
+	client, err := genai.NewClient(ctx, option.WithAPIKey(apiKey))
+	if err != nil {
+		return "", fmt.Errorf("creating Gemini client: %w", err)
+	}
+	defer client.Close()
+
+	model := client.GenerativeModel(modelName)
+	model.SetTemperature(0.0)
+	model.SetTopK(1)
+	model.ResponseMIMEType = "application/json"
+	model.ResponseSchema = buildPostureSchema()
+
+	var parts []genai.Part
+	parts = append(parts, genai.Text(promptText))
+
+	addImage := func(label, path string) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			parts = append(parts, genai.Text(fmt.Sprintf("[%s] - Image not found", label)))
+			return
+		}
+		mimeType := "jpeg"
+		if strings.HasSuffix(strings.ToLower(path), ".png") {
+			mimeType = "png"
+		}
+		parts = append(parts, genai.Text(fmt.Sprintf("[%s]", label)))
+		parts = append(parts, genai.ImageData(mimeType, data))
+	}
+
+	addImage("Front View", frontPath)
+	addImage("Left Side View", leftPath)
+	addImage("Right Side View", rightPath)
+	addImage("Back View", backPath)
+
+	var result strings.Builder
+	iter := model.GenerateContentStream(ctx, parts...)
+	for {
+		resp, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("Gemini stream error: %w", err)
+		}
+		for _, cand := range resp.Candidates {
+			if cand.Content == nil {
+				continue
+			}
+			for _, part := range cand.Content.Parts {
+				if txt, ok := part.(genai.Text); ok {
+					result.WriteString(string(txt))
+					onChunk(string(txt))
+				}
+			}
+		}
+	}
+	return result.String(), nil
+}
+
 func runOpenAI(ctx context.Context, promptText, frontPath, leftPath, rightPath, backPath string) string {
 	apiKey := os.Getenv("OPENAI_API_KEY")
 	if apiKey == "" {
@@ -143,6 +223,93 @@ func runOpenAI(ctx context.Context, promptText, frontPath, leftPath, rightPath,
 	return resp.Choices[0].Message.Content
 }
 
+// runOpenAIStream mirrors runOpenAI but hands each delta's content to
+// onChunk as it streams in, the same early-feedback behavior
+// runGeminiStream gives Gemini callers.
+func runOpenAIStream(ctx context.Context, promptText, frontPath, leftPath, rightPath, backPath string, onChunk func(string)) (string, error) {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		return "", fmt.Errorf("OPENAI_API_KEY not set")
+	}
+
+	modelName := os.Getenv("OPENAI_MODEL")
+	if modelName == "" {
+		modelName = "gpt-4o-mini"
+	}
+
+	client := openai.NewClient(apiKey)
+
+	encodeImage := func(path string) (string, string) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", ""
+		}
+		mimeType := "image/jpeg"
+		if strings.HasSuffix(strings.ToLower(path), ".png") {
+			mimeType = "image/png"
+		}
+		return base64.StdEncoding.EncodeToString(data), mimeType
+	}
+
+	var content []openai.ChatMessagePart
+	content = append(content, openai.ChatMessagePart{Type: openai.ChatMessagePartTypeText, Text: promptText})
+
+	addImage := func(label, path string) {
+		b64, mime := encodeImage(path)
+		if b64 == "" {
+			content = append(content, openai.ChatMessagePart{Type: openai.ChatMessagePartTypeText, Text: fmt.Sprintf("[%s] - Image not found", label)})
+			return
+		}
+		content = append(content, openai.ChatMessagePart{Type: openai.ChatMessagePartTypeText, Text: fmt.Sprintf("[%s]", label)})
+		content = append(content, openai.ChatMessagePart{
+			Type: openai.ChatMessagePartTypeImageURL,
+			ImageURL: &openai.ChatMessageImageURL{
+				URL:    fmt.Sprintf("data:%s;base64,%s", mime, b64),
+				Detail: openai.ImageURLDetailAuto,
+			},
+		})
+	}
+
+	addImage("Front View", frontPath)
+	addImage("Left Side View", leftPath)
+	addImage("Right Side View", rightPath)
+	addImage("Back View", backPath)
+
+	stream, err := client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{
+		Model:       modelName,
+		Messages:    []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, MultiContent: content}},
+		Temperature: 0,
+		ResponseFormat: &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONObject,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("OpenAI stream error: %w", err)
+	}
+	defer stream.Close()
+
+	var result strings.Builder
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("OpenAI stream error: %w", err)
+		}
+		if len(resp.Choices) == 0 {
+			continue
+		}
+		delta := resp.Choices[0].Delta.Content
+		if delta == "" {
+			continue
+		}
+		result.WriteString(delta)
+		onChunk(delta)
+	}
+	return result.String(), nil
+}
+
 func buildPostureSchema() *genai.Schema {
 	metricSchema := &genai.Schema{
 		Type: genai.TypeObject,
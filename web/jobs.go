@@ -0,0 +1,205 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// jobEvent is one SSE message: an event name and its JSON-encoded
+// data payload.
+type jobEvent struct {
+	name string
+	data string
+}
+
+// Job tracks one /analyze/start run so multiple browser tabs (or a
+// reconnecting client) can attach to /analyze/stream and see the same
+// progress, including everything that happened before they connected.
+type Job struct {
+	mu          sync.Mutex
+	events      []jobEvent
+	done        bool
+	subscribers []chan jobEvent
+}
+
+func (j *Job) emit(name string, data interface{}) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		encoded = []byte(fmt.Sprintf(`{"error":%q}`, err.Error()))
+	}
+	ev := jobEvent{name: name, data: string(encoded)}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.events = append(j.events, ev)
+	for _, sub := range j.subscribers {
+		select {
+		case sub <- ev:
+		default:
+		}
+	}
+}
+
+// finish marks the job complete and closes every subscriber channel
+// so their stream handlers return.
+func (j *Job) finish() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.done = true
+	for _, sub := range j.subscribers {
+		close(sub)
+	}
+	j.subscribers = nil
+}
+
+// subscribe replays every event recorded so far into a fresh channel
+// and, unless the job already finished, registers it for future
+// events. The returned unsubscribe func must be called once the
+// caller stops reading.
+func (j *Job) subscribe() (<-chan jobEvent, func()) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	ch := make(chan jobEvent, len(j.events)+8)
+	for _, ev := range j.events {
+		ch <- ev
+	}
+	if j.done {
+		close(ch)
+		return ch, func() {}
+	}
+	j.subscribers = append(j.subscribers, ch)
+
+	unsubscribe := func() {
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		for i, sub := range j.subscribers {
+			if sub == ch {
+				j.subscribers = append(j.subscribers[:i], j.subscribers[i+1:]...)
+				break
+			}
+		}
+	}
+	return ch, unsubscribe
+}
+
+var (
+	jobsMu sync.Mutex
+	jobs   = map[string]*Job{}
+)
+
+func registerJob(id string) *Job {
+	job := &Job{}
+	jobsMu.Lock()
+	jobs[id] = job
+	jobsMu.Unlock()
+	return job
+}
+
+func lookupJob(id string) (*Job, bool) {
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+	job, ok := jobs[id]
+	return job, ok
+}
+
+// handleAnalyzeStart saves the uploaded images, registers a Job for
+// the run, and kicks off the analysis pipeline in the background,
+// returning immediately with the job id so the client can open an
+// EventSource against /analyze/stream?id=<id>.
+func handleAnalyzeStart(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "POST required", http.StatusMethodNotAllowed)
+		return
+	}
+	r.ParseMultipartForm(50 << 20) // 50MB max
+
+	timestamp, outputDir, paths, err := saveUploads(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	job := registerJob(timestamp)
+	job.emit("upload_saved", map[string]string{"id": timestamp})
+
+	go runAnalysisPipeline(job, timestamp, outputDir, paths)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"id": timestamp})
+}
+
+// runAnalysisPipeline is the staged version of finalizeReport: the
+// same steps handleAnalyze runs synchronously, but each one emits an
+// SSE event as it completes (or, for the LLM call, as partial text
+// streams in) so a subscribed client sees progress instead of waiting
+// silently for up to 10 minutes.
+func runAnalysisPipeline(job *Job, timestamp, outputDir string, paths map[string]string) {
+	defer job.finish()
+
+	job.emit("preprocessing_started", map[string]string{})
+	landmarkCount := preprocessPoseViews(paths)
+	job.emit("preprocessing_done", map[string]int{"landmark_count": landmarkCount})
+
+	job.emit("llm_request_sent", map[string]string{})
+	resultText, err := runAnalysisStageStreaming(paths, func(chunk string) {
+		job.emit("llm_chunk", map[string]string{"text": chunk})
+	})
+	if err != nil {
+		job.emit("error", map[string]string{"message": err.Error()})
+		return
+	}
+
+	analysisData, err := finalizeReport(outputDir, resultText, paths)
+	if err != nil {
+		job.emit("error", map[string]string{"message": err.Error()})
+		return
+	}
+	job.emit("schema_validated", map[string]bool{"ok": true})
+
+	score, _ := analysisData["PostureScore"].(int)
+	job.emit("score_computed", map[string]int{"posture_score": score})
+
+	job.emit("report_ready", map[string]string{"url": "/report/" + timestamp})
+}
+
+// handleAnalyzeStream streams a Job's events to the client as
+// Server-Sent Events, replaying any history before following along
+// live, so reattaching from a new tab (or after a reload) picks up
+// where the job actually is.
+func handleAnalyzeStream(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	job, ok := lookupJob(id)
+	if !ok {
+		http.Error(w, "unknown job id", http.StatusNotFound)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	events, unsubscribe := job.subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case ev, open := <-events:
+			if !open {
+				return
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.name, ev.data)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pranjal-lnct/PostureAnalysis/pkg/pose"
+)
+
+// preprocessPoseViews runs keypoint extraction on the four uploaded
+// view images through the configured pose backend and returns the
+// total landmark count across all views, for the preprocessing_done
+// SSE event. It returns 0 (not an error) when no pose backend is
+// configured or a view fails to extract, the same "best effort, warn
+// and continue" convention the CLI's preprocessPose uses.
+func preprocessPoseViews(paths map[string]string) int {
+	baseURL := pose.BackendURL("")
+	if baseURL == "" {
+		return 0
+	}
+	preprocessor := pose.New(baseURL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	total := 0
+	for _, view := range []string{"front", "left", "right", "back"} {
+		path, ok := paths[view]
+		if !ok {
+			continue
+		}
+		result, err := preprocessor.Process(ctx, path)
+		if err != nil {
+			log.Printf("Warning: pose extraction failed for %s view: %v", view, err)
+			continue
+		}
+		total += len(result.Landmarks)
+	}
+	return total
+}
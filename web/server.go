@@ -38,6 +38,8 @@ func main() {
 
 	http.HandleFunc("/", handleUpload)
 	http.HandleFunc("/analyze", handleAnalyze)
+	http.HandleFunc("/analyze/start", handleAnalyzeStart)
+	http.HandleFunc("/analyze/stream", handleAnalyzeStream)
 	http.HandleFunc("/report/", handleReport)
 
 	port := os.Getenv("PORT")
@@ -65,24 +67,41 @@ func handleAnalyze(w http.ResponseWriter, r *http.Request) {
 
 	r.ParseMultipartForm(50 << 20) // 50MB max
 
-	// Create output directory
-	timestamp := time.Now().Format("2006-01-02_15-04-05")
+	timestamp, outputDir, paths, err := saveUploads(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resultText := runAnalysisStage(paths)
+	if _, err := finalizeReport(outputDir, resultText, paths); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Redirect to report
+	http.Redirect(w, r, "/report/"+timestamp, http.StatusSeeOther)
+}
+
+// saveUploads creates this run's output directory and writes the four
+// uploaded view images into it, the upload-handling half of what used
+// to be one monolithic handleAnalyze.
+func saveUploads(r *http.Request) (timestamp, outputDir string, paths map[string]string, err error) {
+	timestamp = time.Now().Format("2006-01-02_15-04-05")
 	outputBase := os.Getenv("OUTPUT_DIR")
 	if outputBase == "" {
 		outputBase = "../output"
 	}
-	outputDir := filepath.Join(outputBase, timestamp)
+	outputDir = filepath.Join(outputBase, timestamp)
 	os.MkdirAll(outputDir, 0755)
 
-	// Save uploaded files
 	views := []string{"front", "back", "left", "right"}
-	paths := make(map[string]string)
+	paths = make(map[string]string)
 
 	for _, view := range views {
-		file, header, err := r.FormFile(view)
-		if err != nil {
-			http.Error(w, "Missing "+view+" image", http.StatusBadRequest)
-			return
+		file, header, ferr := r.FormFile(view)
+		if ferr != nil {
+			return timestamp, outputDir, nil, fmt.Errorf("missing %s image", view)
 		}
 		defer file.Close()
 
@@ -93,8 +112,12 @@ func handleAnalyze(w http.ResponseWriter, r *http.Request) {
 		dst.Close()
 		paths[view] = savePath
 	}
+	return timestamp, outputDir, paths, nil
+}
 
-	// Run analysis
+// runAnalysisStage calls whichever provider is configured and returns
+// its raw JSON result text.
+func runAnalysisStage(paths map[string]string) string {
 	promptFile := os.Getenv("PROMPT_FILE")
 	if promptFile == "" {
 		promptFile = "../prompt.txt"
@@ -110,17 +133,44 @@ func handleAnalyze(w http.ResponseWriter, r *http.Request) {
 		provider = "gemini"
 	}
 
-	var resultText string
 	if provider == "openai" {
-		resultText = runOpenAI(ctx, promptText, paths["front"], paths["left"], paths["right"], paths["back"])
-	} else {
-		resultText = runGemini(ctx, promptText, paths["front"], paths["left"], paths["right"], paths["back"])
+		return runOpenAI(ctx, promptText, paths["front"], paths["left"], paths["right"], paths["back"])
 	}
+	return runGemini(ctx, promptText, paths["front"], paths["left"], paths["right"], paths["back"])
+}
 
-	// Save analysis
+// runAnalysisStageStreaming is runAnalysisStage's SSE counterpart: it
+// calls the streaming variant of whichever provider is configured and
+// hands each partial chunk to onChunk as it arrives.
+func runAnalysisStageStreaming(paths map[string]string, onChunk func(string)) (string, error) {
+	promptFile := os.Getenv("PROMPT_FILE")
+	if promptFile == "" {
+		promptFile = "../prompt.txt"
+	}
+	promptBytes, _ := os.ReadFile(promptFile)
+	promptText := string(promptBytes)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	provider := os.Getenv("AI_PROVIDER")
+	if provider == "" {
+		provider = "gemini"
+	}
+
+	if provider == "openai" {
+		return runOpenAIStream(ctx, promptText, paths["front"], paths["left"], paths["right"], paths["back"], onChunk)
+	}
+	return runGeminiStream(ctx, promptText, paths["front"], paths["left"], paths["right"], paths["back"], onChunk)
+}
+
+// finalizeReport parses the raw result, builds the region/score data,
+// generates exercise recommendations and renders report.html. It
+// returns the parsed analysisData so callers (e.g. the SSE pipeline)
+// can report on intermediate state as each step completes.
+func finalizeReport(outputDir, resultText string, paths map[string]string) (map[string]interface{}, error) {
 	os.WriteFile(filepath.Join(outputDir, "analysis.json"), []byte(resultText), 0644)
 
-	// Generate report
 	var analysisData map[string]interface{}
 	cleanJson := strings.TrimSpace(resultText)
 	cleanJson = strings.TrimPrefix(cleanJson, "```json")
@@ -128,11 +178,9 @@ func handleAnalyze(w http.ResponseWriter, r *http.Request) {
 	cleanJson = strings.TrimSuffix(cleanJson, "```")
 
 	if err := json.Unmarshal([]byte(cleanJson), &analysisData); err != nil {
-		http.Error(w, "Failed to parse analysis", http.StatusInternalServerError)
-		return
+		return nil, fmt.Errorf("failed to parse analysis: %w", err)
 	}
 
-	// Inject image paths
 	analysisData["input_images"] = map[string]string{
 		"front": filepath.Base(paths["front"]),
 		"left":  filepath.Base(paths["left"]),
@@ -140,21 +188,16 @@ func handleAnalyze(w http.ResponseWriter, r *http.Request) {
 		"back":  filepath.Base(paths["back"]),
 	}
 
-	// Build regions and score
 	buildRegionsAndScore(analysisData)
-
-	// Generate exercises
 	analysisData["Exercises"] = generateExerciseRecommendations(analysisData)
 
-	// Generate HTML report
 	templateFile := os.Getenv("TEMPLATE_FILE")
 	if templateFile == "" {
 		templateFile = "../template.html"
 	}
 	generateHTMLReport(outputDir, analysisData, templateFile)
 
-	// Redirect to report
-	http.Redirect(w, r, "/report/"+timestamp, http.StatusSeeOther)
+	return analysisData, nil
 }
 
 func handleReport(w http.ResponseWriter, r *http.Request) {
@@ -265,7 +308,7 @@ func generateHTMLReport(outputDir string, data map[string]interface{}, tmplPath
 			}
 			return result
 		},
-		"add": func(a, b int) int { return a + b },
+		"add":     func(a, b int) int { return a + b },
 		"toFloat": func(i int) float64 { return float64(i) },
 	}
 